@@ -0,0 +1,240 @@
+// Package backoff provides retry policies and the Retry helper sleeping on the clock.Clock.
+package backoff
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// State describes the retry progress.
+type State struct {
+	// Attempt is the number of failed attempts so far, it starts from 1.
+	Attempt int
+	// Elapsed is the time elapsed since the first attempt started.
+	Elapsed time.Duration
+	// Prev is the previous delay, it's zero after the first attempt.
+	Prev time.Duration
+}
+
+// Policy decides how long to wait before the next attempt.
+type Policy interface {
+	// Next returns the delay before the next attempt
+	// or false if there should be no more attempts.
+	Next(s State) (time.Duration, bool)
+}
+
+// Constant is the policy with the constant delay between attempts.
+type Constant struct {
+	Delay time.Duration
+}
+
+// Next implements Policy.
+func (p Constant) Next(State) (time.Duration, bool) {
+	return p.Delay, true
+}
+
+// Exponential is the policy with the exponentially growing delay.
+// The delay before the n-th retry is Initial * Multiplier^(n-1) limited by Max
+// and randomized by Jitter.
+type Exponential struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max is the maximum delay, zero means no limit.
+	Max time.Duration
+	// Multiplier is the delay growth factor, 2 is used if it's not greater than 1.
+	Multiplier float64
+	// Jitter is the randomization factor in [0, 1].
+	// The delay d is randomized to [d*(1-Jitter), d*(1+Jitter)].
+	Jitter float64
+	// Rand returns a pseudo-random number in [0, 1).
+	// math/rand's Float64 is used if it's nil.
+	Rand func() float64
+}
+
+// Next implements Policy.
+func (p Exponential) Next(s State) (time.Duration, bool) {
+	multiplier := p.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2
+	}
+
+	d := float64(p.Initial) * math.Pow(multiplier, float64(s.Attempt-1))
+	if p.Jitter > 0 {
+		d = d * (1 - p.Jitter + 2*p.Jitter*random(p.Rand))
+	}
+
+	return capDuration(d, p.Max), true
+}
+
+// DecorrelatedJitter is the policy with the "decorrelated jitter" delays.
+// Every delay is a random value between Base and three times the previous delay
+// limited by Max.
+type DecorrelatedJitter struct {
+	// Base is the minimum delay.
+	Base time.Duration
+	// Max is the maximum delay, zero means no limit.
+	Max time.Duration
+	// Rand returns a pseudo-random number in [0, 1).
+	// math/rand's Float64 is used if it's nil.
+	Rand func() float64
+}
+
+// Next implements Policy.
+func (p DecorrelatedJitter) Next(s State) (time.Duration, bool) {
+	prev := s.Prev
+	if prev < p.Base {
+		prev = p.Base
+	}
+
+	upper := 3 * float64(prev)
+	d := float64(p.Base) + (upper-float64(p.Base))*random(p.Rand)
+
+	return capDuration(d, p.Max), true
+}
+
+// WithMaxRetries limits the policy with the maximum count of retries.
+func WithMaxRetries(p Policy, n int) Policy {
+	return maxRetries{Policy: p, n: n}
+}
+
+// maxRetries is the policy that stops after the specified count of retries.
+type maxRetries struct {
+	Policy
+	n int
+}
+
+// Next implements Policy.
+func (p maxRetries) Next(s State) (time.Duration, bool) {
+	if s.Attempt > p.n {
+		return 0, false
+	}
+	return p.Policy.Next(s)
+}
+
+// WithMaxElapsedTime limits the policy with the maximum elapsed time.
+// There are no more attempts if the next one would start after
+// the specified time since the first attempt.
+func WithMaxElapsedTime(p Policy, d time.Duration) Policy {
+	return maxElapsedTime{Policy: p, d: d}
+}
+
+// maxElapsedTime is the policy that stops after the specified elapsed time.
+type maxElapsedTime struct {
+	Policy
+	d time.Duration
+}
+
+// Next implements Policy.
+func (p maxElapsedTime) Next(s State) (time.Duration, bool) {
+	delay, ok := p.Policy.Next(s)
+	if !ok || s.Elapsed+delay > p.d {
+		return 0, false
+	}
+	return delay, true
+}
+
+// PermanentError is the error that stops retrying.
+type PermanentError struct {
+	Err error
+}
+
+// Error implements error.
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps the error, so Retry stops and returns it immediately.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// Retry calls fn until it succeeds, the policy stops retrying,
+// fn returns a permanent error or the context is done.
+// It sleeps between attempts using the clock's timer.
+// It returns nil on success, the last fn's error if retrying was stopped
+// by the policy or the permanent error, and the context's error otherwise.
+func Retry(ctx context.Context, c clock.Clock, p Policy, fn func(ctx context.Context) error) error {
+	start := c.Now()
+	var prev time.Duration
+
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *PermanentError
+		if errors.As(err, &permanent) {
+			return permanent.Err
+		}
+
+		delay, ok := p.Next(State{
+			Attempt: attempt,
+			Elapsed: c.Since(start),
+			Prev:    prev,
+		})
+		if !ok {
+			return err
+		}
+		prev = delay
+
+		if err := sleep(ctx, c, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep waits for the specified duration using the clock's timer.
+// It returns the context's error if the context is done first.
+func sleep(ctx context.Context, c clock.Clock, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := c.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.Chan():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// random returns a pseudo-random number in [0, 1) from the specified source.
+func random(source func() float64) float64 {
+	if source == nil {
+		return rand.Float64()
+	}
+	return source()
+}
+
+// capDuration converts the float duration to time.Duration limited by max.
+// Zero max means no limit except the time.Duration's range.
+func capDuration(d float64, max time.Duration) time.Duration {
+	if max > 0 && d > float64(max) {
+		return max
+	}
+	if d >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
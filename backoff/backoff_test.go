@@ -0,0 +1,215 @@
+package backoff_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/backoff"
+)
+
+func TestExponential(t *testing.T) {
+	p := backoff.Exponential{Initial: 100 * time.Millisecond, Max: time.Second}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, exp := range expected {
+		d, ok := p.Next(backoff.State{Attempt: i + 1})
+		if !ok {
+			t.Fatalf("Unexpected stop on attempt %d", i+1)
+		}
+		if d != exp {
+			t.Fatalf("Unexpected delay on attempt %d, expected=%s, actual=%s", i+1, exp, d)
+		}
+	}
+
+	if d, _ := p.Next(backoff.State{Attempt: 1000}); d != time.Second {
+		t.Fatalf("Unexpected delay on overflow, expected=%s, actual=%s", time.Second, d)
+	}
+}
+
+func TestExponentialJitter(t *testing.T) {
+	p := backoff.Exponential{
+		Initial:    time.Second,
+		Multiplier: 3,
+		Jitter:     0.5,
+	}
+
+	cases := []struct {
+		rand     float64
+		expected time.Duration
+	}{
+		{0, 1500 * time.Millisecond},
+		{0.5, 3 * time.Second},
+		{0.75, 3750 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		p.Rand = func() float64 { return tc.rand }
+		d, _ := p.Next(backoff.State{Attempt: 2})
+		if d != tc.expected {
+			t.Fatalf("Unexpected delay for rand=%v, expected=%s, actual=%s", tc.rand, tc.expected, d)
+		}
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	p := backoff.DecorrelatedJitter{
+		Base: time.Second,
+		Max:  5 * time.Second,
+		Rand: func() float64 { return 0.5 },
+	}
+
+	cases := []struct {
+		prev     time.Duration
+		expected time.Duration
+	}{
+		{0, 2 * time.Second},
+		{2 * time.Second, 3500 * time.Millisecond},
+		{4 * time.Second, 5 * time.Second},
+	}
+	for _, tc := range cases {
+		d, _ := p.Next(backoff.State{Attempt: 2, Prev: tc.prev})
+		if d != tc.expected {
+			t.Fatalf("Unexpected delay for prev=%s, expected=%s, actual=%s", tc.prev, tc.expected, d)
+		}
+	}
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	p := backoff.WithMaxRetries(backoff.Constant{Delay: time.Second}, 2)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if _, ok := p.Next(backoff.State{Attempt: attempt}); !ok {
+			t.Fatalf("Unexpected stop on attempt %d", attempt)
+		}
+	}
+	if _, ok := p.Next(backoff.State{Attempt: 3}); ok {
+		t.Fatal("Expected stop after the max retries")
+	}
+}
+
+func TestWithMaxElapsedTime(t *testing.T) {
+	p := backoff.WithMaxElapsedTime(backoff.Constant{Delay: time.Second}, 5*time.Second)
+
+	if _, ok := p.Next(backoff.State{Attempt: 1, Elapsed: 4 * time.Second}); !ok {
+		t.Fatal("Unexpected stop within the max elapsed time")
+	}
+	if _, ok := p.Next(backoff.State{Attempt: 1, Elapsed: 4*time.Second + 1}); ok {
+		t.Fatal("Expected stop after the max elapsed time")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	c := clock.NewFakeClock()
+	start := c.Now()
+	p := backoff.Exponential{Initial: time.Second}
+
+	var attempts []time.Duration
+	done := make(chan error)
+	go func() {
+		done <- backoff.Retry(context.Background(), c, p, func(context.Context) error {
+			attempts = append(attempts, c.Since(start))
+			if len(attempts) < 4 {
+				return errors.New("fail")
+			}
+			return nil
+		})
+	}()
+
+	for _, d := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		c.BlockUntil(1)
+		c.Advance(d)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error, expected=nil, actual=%v", err)
+	}
+
+	expected := []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second}
+	if len(attempts) != len(expected) {
+		t.Fatalf("Unexpected attempts count, expected=%d, actual=%d", len(expected), len(attempts))
+	}
+	for i := range expected {
+		if attempts[i] != expected[i] {
+			t.Fatalf("Unexpected attempt %d time, expected=%s, actual=%s", i, expected[i], attempts[i])
+		}
+	}
+}
+
+func TestRetryStopsByPolicy(t *testing.T) {
+	c := clock.NewFakeClock()
+	p := backoff.WithMaxElapsedTime(backoff.Constant{Delay: time.Second}, 2*time.Second)
+	errFail := errors.New("fail")
+
+	attempts := 0
+	done := make(chan error)
+	go func() {
+		done <- backoff.Retry(context.Background(), c, p, func(context.Context) error {
+			attempts++
+			return errFail
+		})
+	}()
+
+	for i := 0; i < 2; i++ {
+		c.BlockUntil(1)
+		c.Advance(time.Second)
+	}
+
+	if err := <-done; err != errFail {
+		t.Fatalf("Unexpected error, expected=%v, actual=%v", errFail, err)
+	}
+	if attempts != 3 {
+		t.Fatalf("Unexpected attempts count, expected=3, actual=%d", attempts)
+	}
+}
+
+func TestRetryPermanent(t *testing.T) {
+	c := clock.NewFakeClock()
+	errFail := errors.New("fail")
+
+	attempts := 0
+	err := backoff.Retry(context.Background(), c, backoff.Constant{Delay: time.Second}, func(context.Context) error {
+		attempts++
+		return backoff.Permanent(errFail)
+	})
+
+	if err != errFail {
+		t.Fatalf("Unexpected error, expected=%v, actual=%v", errFail, err)
+	}
+	if attempts != 1 {
+		t.Fatalf("Unexpected attempts count, expected=1, actual=%d", attempts)
+	}
+	if backoff.Permanent(nil) != nil {
+		t.Fatal("Expected nil permanent error for nil error")
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	c := clock.NewFakeClock()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		done <- backoff.Retry(ctx, c, backoff.Constant{Delay: time.Hour}, func(context.Context) error {
+			return errors.New("fail")
+		})
+	}()
+
+	c.BlockUntil(1)
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Unexpected error, expected=%v, actual=%v", context.Canceled, err)
+	}
+	if n := c.WaitersCount(); n != 0 {
+		t.Fatalf("Unexpected waiters count after cancel, expected=0, actual=%d", n)
+	}
+}
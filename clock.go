@@ -1,6 +1,7 @@
 package clock
 
 import (
+	"context"
 	"errors"
 	"time"
 )
@@ -40,8 +41,21 @@ func (realClock) After(d time.Duration) <-chan time.Time {
 }
 
 // AfterFunc implements Clock.
+// The returned timer's channel receives the time the timer fired at
+// once the callback returns.
 func (realClock) AfterFunc(d time.Duration, f func()) Timer {
-	return realTimer{Timer: time.AfterFunc(d, f)}
+	ch := make(chan time.Time, 1)
+	timer := time.AfterFunc(d, func() {
+		firedAt := time.Now()
+		f()
+
+		select {
+		case ch <- firedAt:
+		default:
+		}
+	})
+
+	return realTimer{Timer: timer, ch: ch}
 }
 
 // Since implements Clock.
@@ -73,27 +87,118 @@ func (realClock) NewTicker(d time.Duration) Ticker {
 // NewTimer implements Clock.
 // It returns a new instance of the real timer.
 func (realClock) NewTimer(d time.Duration) Timer {
-	return realTimer{Timer: time.NewTimer(d)}
+	timer := time.NewTimer(d)
+	return realTimer{Timer: timer, ch: timer.C}
+}
+
+// Labeled returns the clock that labels all created timers/tickers/sleepers
+// with the specified label if the specified clock is a fake clock
+// or embeds one.
+// Other clocks are returned as is.
+// Labels let tests find specific timers, see FakeClock.BlockUntilLabel and FakeClock.FireLabel.
+func Labeled(c Clock, label string) Clock {
+	if lc, ok := c.(labeler); ok {
+		return lc.Labeled(label)
+	}
+	return c
+}
+
+// labeler is implemented by FakeClock and the types embedding it.
+type labeler interface {
+	Labeled(label string) FakeClock
 }
 
 // FakeClock is an internalClock's shallow wrapper.
 // It provides special mock methods such Advance or WaitersCount.
 type FakeClock struct {
 	*internalClock
+	label string
 }
 
-// NewFakeClock returns a new instance of the fake clock.
-func NewFakeClock() FakeClock {
-	return FakeClock{
-		internalClock: newInternalClock(time.Time{}),
+// Option configures the fake clock.
+type Option func(*internalClock)
+
+// WithTickerCatchUp makes all the fake clock's tickers deliver every elapsed period.
+// By default a ticker delivers a single tick per Advance call
+// and drops the rest like a real ticker with a slow receiver.
+// In the catch-up mode Advance waits until each tick is received
+// before the next one is produced, so Advance blocks
+// while there is no one receiving from the ticker's channel.
+// Tick channels never catch up since they can't be stopped.
+func WithTickerCatchUp() Option {
+	return func(c *internalClock) {
+		c.tickerCatchUp = true
 	}
 }
 
+// WithTickerCatchUpLabels makes the tickers with one of the specified labels
+// deliver every elapsed period, see WithTickerCatchUp for details.
+// It lets tests opt in the tickers created by the code under test, see Labeled.
+func WithTickerCatchUpLabels(labels ...string) Option {
+	return func(c *internalClock) {
+		if c.catchUpLabels == nil {
+			c.catchUpLabels = make(map[string]bool)
+		}
+		for _, label := range labels {
+			c.catchUpLabels[label] = true
+		}
+	}
+}
+
+// WithSyncCallbacks makes the fake clock call AfterFunc callbacks synchronously.
+// By default every callback is called in its own goroutine like the real clock does.
+// In the synchronous mode Advance calls triggered callbacks one by one
+// in the trigger order and returns when all of them are done.
+// Callbacks are called without holding the clock's lock,
+// so they can create, stop and reset timers, read and set the time.
+// The time can't be moved until a callback returns, so callbacks must not call
+// Advance, AdvanceTo, AdvanceToNext, RunUntilIdle and FireLabel, which deadlock,
+// and must not wait for the clock's timers, e.g. by Sleep or receiving from After,
+// which never return.
+func WithSyncCallbacks() Option {
+	return func(c *internalClock) {
+		c.syncCallbacks = true
+	}
+}
+
+// WithGo123Timers makes the fake timers and tickers follow
+// the channel semantics introduced in Go 1.23.
+// Stop and Reset discard a value that is not received yet,
+// so no stale value is received after they return,
+// and the timer with such value is still considered active by Stop and Reset.
+// By default the pre Go 1.23 semantics is used:
+// a value sent before Stop or Reset stays in the channel.
+// Unlike Go 1.23 channels, the fake ones are still buffered, so cap and len of them are 1.
+func WithGo123Timers() Option {
+	return func(c *internalClock) {
+		c.syncTimerChans = true
+	}
+}
+
+// WithCreationStacks makes the fake clock capture the stack trace
+// of every timer/ticker/sleeper at its creation, see WaiterInfo.
+// Capturing is costly, so it's off by default and AssertNoLeaks turns it on.
+func WithCreationStacks() Option {
+	return func(c *internalClock) {
+		c.creationStacks = true
+	}
+}
+
+// NewFakeClock returns a new instance of the fake clock.
+func NewFakeClock(opts ...Option) FakeClock {
+	return NewFakeClockAt(time.Time{}, opts...)
+}
+
 // NewFakeClockAt returns a new instance of the fake clock.
 // Specified time will be used as a current clock's time.
-func NewFakeClockAt(t time.Time) FakeClock {
+func NewFakeClockAt(t time.Time, opts ...Option) FakeClock {
+	c := newInternalClock(t)
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	return FakeClock{
-		internalClock: newInternalClock(t),
+		internalClock: c,
 	}
 }
 
@@ -103,6 +208,55 @@ func (c FakeClock) Advance(d time.Duration) {
 	c.moveTimeForward(d)
 }
 
+// Set sets current clock's wall time to the specified time.
+// The wall time can be moved both forward and backward
+// to model wall clock jumps like NTP corrections or VM resumes.
+// The fake clock's monotonic time isn't affected:
+// active timers/tickers/sleepers keep their remaining durations
+// the same way the Go runtime timers do,
+// so they're neither triggered nor delayed by the jump.
+// Use Advance to model the time elapsing.
+func (c FakeClock) Set(t time.Time) {
+	c.setTime(t)
+}
+
+// Skew moves current clock's wall time by the specified duration.
+// It's a shortcut for Set(Now().Add(d)), see Set for details.
+func (c FakeClock) Skew(d time.Duration) {
+	c.skewTime(d)
+}
+
+// AdvanceTo moves current clock's time forward to the specified time.
+// It does nothing if the specified time is before the current time.
+// It returns the number of triggered timers/tickers/sleepers.
+func (c FakeClock) AdvanceTo(t time.Time) int {
+	return c.moveTimeTo(t)
+}
+
+// AdvanceToNext moves current clock's time forward
+// to the earliest active timer's/ticker's/sleeper's trigger time.
+// It returns the number of triggered timers/tickers/sleepers
+// and false if there is nothing to wait for.
+func (c FakeClock) AdvanceToNext() (fired int, ok bool) {
+	return c.moveTimeToNext()
+}
+
+// RunUntilIdle keeps moving current clock's time to the next trigger time
+// until there are no active timers/tickers/sleepers or maxSteps is reached.
+// Active tickers never let the clock become idle.
+// It doesn't wait for the code reacting to triggered timers,
+// so timers registered asynchronously after the last step are not taken into account.
+// It returns the number of made steps and true if the clock became idle.
+func (c FakeClock) RunUntilIdle(maxSteps int) (steps int, idle bool) {
+	for steps < maxSteps {
+		if _, ok := c.AdvanceToNext(); !ok {
+			return steps, true
+		}
+		steps++
+	}
+	return steps, c.WaitersCount() == 0
+}
+
 // WaitersCount returns current active timers/tickers/sleepers count.
 func (c FakeClock) WaitersCount() int {
 	return c.waitersCount()
@@ -110,41 +264,202 @@ func (c FakeClock) WaitersCount() int {
 
 // BlockUntil waits for the specified count of active timers/tickers/sleepers.
 func (c FakeClock) BlockUntil(n int) {
-	for c.waitersCount() != n {
-		time.Sleep(time.Millisecond)
+	c.waitForWaiters(context.Background(), n)
+}
+
+// BlockUntilContext waits for the specified count of active timers/tickers/sleepers.
+// It returns the context's error if the context is done before that.
+func (c FakeClock) BlockUntilContext(ctx context.Context, n int) error {
+	return c.waitForWaiters(ctx, n)
+}
+
+// TB is the part of testing.TB used by the fake clock's test helpers.
+// It keeps the testing package out of the binaries using the clock.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Cleanup(f func())
+}
+
+// MustBlockUntil waits for the specified count of active timers/tickers/sleepers
+// at most timeout of the real time.
+// The test fails with the list of active timers/tickers/sleepers on timeout.
+func (c FakeClock) MustBlockUntil(t TB, n int, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := c.waitForWaiters(ctx, n); err != nil {
+		t.Fatalf("BlockUntil(%d) timed out after %s: %s", n, timeout, c.DescribeWaiters())
+	}
+}
+
+// Labeled returns the fake clock sharing the same time and waiters,
+// which labels all created timers/tickers/sleepers with the specified label.
+func (c FakeClock) Labeled(label string) FakeClock {
+	c.label = label
+	return c
+}
+
+// BlockUntilLabel waits for an active waiter with the specified label.
+func (c FakeClock) BlockUntilLabel(label string) {
+	c.waitForLabel(context.Background(), label)
+}
+
+// BlockUntilLabelContext waits for an active waiter with the specified label.
+// It returns the context's error if the context is done before that.
+func (c FakeClock) BlockUntilLabelContext(ctx context.Context, label string) error {
+	return c.waitForLabel(ctx, label)
+}
+
+// FireLabel triggers all active waiters with the specified label
+// without moving current clock's time.
+// Fired tickers deliver the current time and tick again a period later.
+// It returns the number of triggered timers/tickers/sleepers.
+func (c FakeClock) FireLabel(label string) int {
+	return c.fireLabel(label)
+}
+
+// WaitersCountKind returns current active waiters count of the specified kind.
+func (c FakeClock) WaitersCountKind(kind WaiterKind) int {
+	return c.kindCount(kind)
+}
+
+// BlockUntilKind waits for the specified count of active waiters of the specified kind.
+// Waiters of other kinds are not taken into account,
+// e.g. BlockUntilKind(KindSleeper, 2) waits for 2 goroutines blocked in Sleep
+// regardless of active timers and tickers.
+func (c FakeClock) BlockUntilKind(kind WaiterKind, n int) {
+	c.waitForKind(context.Background(), kind, n)
+}
+
+// BlockUntilKindContext waits for the specified count of active waiters of the specified kind.
+// It returns the context's error if the context is done before that.
+func (c FakeClock) BlockUntilKindContext(ctx context.Context, kind WaiterKind, n int) error {
+	return c.waitForKind(ctx, kind, n)
+}
+
+// Waiters returns descriptions of active timers/tickers/sleepers in the trigger order.
+func (c FakeClock) Waiters() []WaiterInfo {
+	return c.waiters()
+}
+
+// DescribeWaiters returns a human readable list of active timers/tickers/sleepers
+// in the trigger order, it's handy for test failure messages.
+func (c FakeClock) DescribeWaiters() string {
+	return c.describeWaiters()
+}
+
+// AssertNoLeaks checks at the test cleanup that there are no active timers/tickers/sleepers.
+// Waiters labeled with one of the ignored labels are allowed to stay active.
+// Every leaked waiter is reported with the stack trace captured at its creation,
+// so it turns on capturing the stacks of the waiters created after the call.
+func (c FakeClock) AssertNoLeaks(t TB, ignoredLabels ...string) {
+	t.Helper()
+
+	c.enableCreationStacks()
+
+	t.Cleanup(func() {
+		t.Helper()
+
+		for _, w := range c.leaks(ignoredLabels) {
+			t.Errorf("leaked %s\n%s", w, w.Stack())
+		}
+	})
+}
+
+// leaks returns active waiters except the ones with the ignored labels.
+func (c FakeClock) leaks(ignoredLabels []string) []WaiterInfo {
+	var leaks []WaiterInfo
+
+	for _, w := range c.Waiters() {
+		ignored := false
+		for _, label := range ignoredLabels {
+			if w.Label == label {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			leaks = append(leaks, w)
+		}
+	}
+
+	return leaks
+}
+
+// RunningCallbacks returns current count of triggered AfterFunc callbacks
+// that are not finished yet.
+func (c FakeClock) RunningCallbacks() int {
+	return c.callbacksCount()
+}
+
+// WaitCallbacks waits until all triggered AfterFunc callbacks are finished.
+// It returns the context's error if the context is done before that.
+func (c FakeClock) WaitCallbacks(ctx context.Context) error {
+	return c.waitForCallbacks(ctx)
+}
+
+// Subscribe returns a channel of the waiter events:
+// timers/tickers/sleepers registration, stop, reset and trigger.
+// Events are delivered in order, they're queued while no one is receiving.
+// The cancel function must be called to release the subscription,
+// the events channel is closed after that.
+func (c FakeClock) Subscribe() (events <-chan WaiterEvent, cancel func()) {
+	s := c.subscribe()
+	return s.ch, func() {
+		c.unsubscribe(s)
 	}
 }
 
 // Now implements Clock.
+// It returns current clock's wall time.
 func (c FakeClock) Now() time.Time {
 	return c.getCurrentTime()
 }
 
 // After implements Clock.
 func (c FakeClock) After(d time.Duration) <-chan time.Time {
-	return c.NewTimer(d).Chan()
+	return c.newInternalTimer(KindAfter, c.label, d, false, nil).ch
 }
 
 // AfterFunc implements Clock.
+// The returned timer's channel receives the time the timer fired at
+// once the callback returns.
 func (c FakeClock) AfterFunc(d time.Duration, f func()) Timer {
 	return mockTimer{
-		internalTimer: c.newInternalTimer(d, false, f),
+		internalTimer: c.newInternalTimer(KindAfterFunc, c.label, d, false, f),
 	}
 }
 
 // Since implements Clock.
+// Like the real clock it measures the monotonic time,
+// so the result isn't affected by Set and Skew calls
+// made after t was obtained from Now.
+// The fake time.Time values don't carry the monotonic reading,
+// so the wall time is converted back to the clock's monotonic time.
+// A wall time repeated after the wall time moved backward is converted
+// to the moment it was observed by Now or received from a timer,
+// other wall times are converted to the first moment the wall time passed over them.
+// The current wall offset is used for the times the wall time never passed over.
+// Only the latest 64 Set/Skew calls and 1024 repeated wall times are remembered.
 func (c FakeClock) Since(t time.Time) time.Duration {
-	return c.Now().Sub(t)
+	return c.since(t)
 }
 
 // Until implements Clock.
+// Like Since it measures the monotonic time.
+// The current wall offset is used if t is in the future of the current wall time,
+// otherwise t is converted the same way as in Since.
 func (c FakeClock) Until(t time.Time) time.Duration {
-	return t.Sub(c.Now())
+	return c.until(t)
 }
 
 // Sleep implements Clock.
 func (c FakeClock) Sleep(d time.Duration) {
-	<-c.NewTimer(d).Chan()
+	<-c.newInternalTimer(KindSleeper, c.label, d, false, nil).ch
 }
 
 // Tick implements Clock.
@@ -152,7 +467,7 @@ func (c FakeClock) Tick(d time.Duration) <-chan time.Time {
 	if d <= 0 {
 		return nil
 	}
-	return c.NewTicker(d).Chan()
+	return c.newInternalTimer(KindTick, c.label, d, false, nil).ch
 }
 
 // NewTicker implements Clock.
@@ -162,7 +477,7 @@ func (c FakeClock) NewTicker(d time.Duration) Ticker {
 		panic(errors.New("non-positive interval for NewTicker"))
 	}
 	return mockTicker{
-		internalTimer: c.newInternalTimer(d, true, nil),
+		internalTimer: c.newInternalTimer(KindTicker, c.label, d, c.tickerCatchUp || c.catchUpLabels[c.label], nil),
 	}
 }
 
@@ -170,6 +485,6 @@ func (c FakeClock) NewTicker(d time.Duration) Ticker {
 // It returns a new instance of the mock timer.
 func (c FakeClock) NewTimer(d time.Duration) Timer {
 	return mockTimer{
-		internalTimer: c.newInternalTimer(d, false, nil),
+		internalTimer: c.newInternalTimer(KindTimer, c.label, d, false, nil),
 	}
 }
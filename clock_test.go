@@ -1,11 +1,15 @@
 package clock_test
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/internal/testrecorder"
 )
 
 func TestFakeClockNow(t *testing.T) {
@@ -111,11 +115,10 @@ func TestFakeClockAfterFunc(t *testing.T) {
 		c.Advance(5 * time.Minute)
 		<-doneCh
 
-		time.Sleep(100 * time.Millisecond)
-		select {
-		case <-timer.Chan():
-			t.Fatal("Unexpected receive from the timer's channel")
-		default:
+		expectedTime := (time.Time{}).Add(10 * time.Minute)
+		actualTime := <-timer.Chan()
+		if expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the timer's channel, expected=%s, actual=%s", expectedTime, actualTime)
 		}
 	})
 
@@ -265,3 +268,625 @@ func TestFakeClockTick(t *testing.T) {
 		}
 	}
 }
+
+func TestFakeClockAdvanceTriggersInOrder(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	start := c.Now()
+
+	type delivery struct {
+		index int
+		now   time.Time
+	}
+	var deliveries []delivery
+
+	durations := []time.Duration{10 * time.Minute, time.Minute, 5 * time.Minute, time.Minute, 30 * time.Second}
+	for i, d := range durations {
+		i := i
+		c.AfterFunc(d, func() {
+			deliveries = append(deliveries, delivery{index: i, now: c.Now()})
+		})
+	}
+
+	c.Advance(time.Hour)
+
+	expectedOrder := []int{4, 1, 3, 2, 0}
+	if len(deliveries) != len(expectedOrder) {
+		t.Fatalf("Unexpected deliveries count, expected=%d, actual=%d", len(expectedOrder), len(deliveries))
+	}
+	for i, index := range expectedOrder {
+		if deliveries[i].index != index {
+			t.Fatalf("Unexpected delivery %d, expected=%d, actual=%d", i, index, deliveries[i].index)
+		}
+		if expectedNow := start.Add(durations[index]); deliveries[i].now != expectedNow {
+			t.Fatalf("Unexpected now during delivery %d, expected=%s, actual=%s", i, expectedNow, deliveries[i].now)
+		}
+	}
+
+	expectedNow := start.Add(time.Hour)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+}
+
+func TestFakeClockAdvanceTimersAndTickersInOrder(t *testing.T) {
+	c := clock.NewFakeClock()
+	start := c.Now()
+
+	durations := []time.Duration{10 * time.Minute, time.Minute, 5 * time.Minute, time.Minute, 30 * time.Second}
+	timers := make([]clock.Timer, 0, len(durations))
+	for _, d := range durations {
+		timers = append(timers, c.NewTimer(d))
+	}
+	ticker := c.NewTicker(2 * time.Minute)
+
+	c.Advance(time.Hour)
+
+	for i, timer := range timers {
+		expectedTime := start.Add(durations[i])
+		select {
+		case actualTime := <-timer.Chan():
+			if expectedTime != actualTime {
+				t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+			}
+		default:
+			t.Fatal("Expected receive from the timer's channel")
+		}
+	}
+
+	expectedTime := start.Add(2 * time.Minute)
+	actualTime := <-ticker.Chan()
+	if expectedTime != actualTime {
+		t.Fatalf("Unexpected time received from the ticker's channel, expected=%s, actual=%s", expectedTime, actualTime)
+	}
+}
+
+func TestFakeClockNonPositiveDuration(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	c.Advance(time.Hour)
+
+	var callbackNow time.Time
+	c.AfterFunc(-time.Minute, func() {
+		callbackNow = c.Now()
+	})
+	timer := c.NewTimer(time.Hour)
+	timer.Reset(-time.Minute)
+
+	c.Advance(time.Second)
+
+	expectedNow := (time.Time{}).Add(time.Hour)
+	if callbackNow != expectedNow {
+		t.Fatalf("Unexpected now during callback, expected=%s, actual=%s", expectedNow, callbackNow)
+	}
+	if actualTime := <-timer.Chan(); actualTime != expectedNow {
+		t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedNow, actualTime)
+	}
+	if now := c.Now(); now != expectedNow.Add(time.Second) {
+		t.Fatalf("Unexpected now result, expected=%s, actual=%s", expectedNow.Add(time.Second), now)
+	}
+}
+
+func TestFakeClockBlockUntilContext(t *testing.T) {
+	t.Run("waiters registered", func(t *testing.T) {
+		c := clock.NewFakeClock()
+
+		errCh := make(chan error)
+		go func() {
+			errCh <- c.BlockUntilContext(context.Background(), 2)
+		}()
+
+		c.NewTimer(time.Minute)
+		c.NewTicker(time.Minute)
+
+		if err := <-errCh; err != nil {
+			t.Fatalf("Unexpected BlockUntilContext error: %s", err)
+		}
+	})
+
+	t.Run("context timed out", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		c.NewTimer(time.Minute)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := c.BlockUntilContext(ctx, 2)
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Unexpected BlockUntilContext error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+var _ clock.TB = testing.TB(nil)
+
+func TestFakeClockMustBlockUntil(t *testing.T) {
+	c := clock.NewFakeClock()
+	c.NewTimer(time.Minute)
+	c.NewTicker(time.Hour)
+
+	r := testrecorder.New(t)
+	c.MustBlockUntil(r, 2, time.Second)
+	if failures := r.Failures(); len(failures) != 0 {
+		t.Fatalf("Unexpected failure: %s", failures)
+	}
+
+	c.MustBlockUntil(r, 3, 10*time.Millisecond)
+	failures := r.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("Unexpected failures count, expected=1, actual=%d", len(failures))
+	}
+	for _, expected := range []string{"timer: duration=1m0s", "ticker: duration=1h0m0s"} {
+		if !strings.Contains(failures[0], expected) {
+			t.Fatalf("Failure message %q doesn't contain %q", failures[0], expected)
+		}
+	}
+}
+
+func TestFakeClockDescribeWaiters(t *testing.T) {
+	c := clock.NewFakeClock()
+	c.Labeled("worker").NewTicker(time.Hour)
+	c.NewTimer(time.Minute)
+
+	lines := strings.Split(c.DescribeWaiters(), "\n\t")
+	expected := []string{
+		"2 waiters registered, current time " + (time.Time{}).String(),
+		"timer: duration=1m0s",
+		`ticker "worker": duration=1h0m0s`,
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Unexpected lines count, expected=%d, actual=%d: %q", len(expected), len(lines), lines)
+	}
+	for i := range expected {
+		if !strings.HasPrefix(lines[i], expected[i]) {
+			t.Fatalf("Unexpected line, expected prefix=%q, actual=%q", expected[i], lines[i])
+		}
+	}
+}
+
+func BenchmarkFakeClockAdvance(b *testing.B) {
+	for _, pending := range []int{10, 1000, 100000} {
+		b.Run(fmt.Sprintf("%d pending timers", pending), func(b *testing.B) {
+			c := clock.NewFakeClock()
+			for i := 0; i < pending; i++ {
+				c.NewTimer(time.Duration(1000000+i) * time.Hour)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				c.Advance(time.Nanosecond)
+			}
+		})
+	}
+}
+
+func BenchmarkFakeClockAdvanceFiring(b *testing.B) {
+	for _, pending := range []int{10, 1000, 100000} {
+		b.Run(fmt.Sprintf("%d pending timers", pending), func(b *testing.B) {
+			c := clock.NewFakeClock()
+			for i := 0; i < pending; i++ {
+				c.NewTimer(time.Duration(1000000+i) * time.Hour)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				timer := c.NewTimer(time.Second)
+				c.Advance(time.Second)
+				<-timer.Chan()
+			}
+		})
+	}
+}
+
+func TestFakeClockAdvanceTo(t *testing.T) {
+	c := clock.NewFakeClock()
+	timer := c.NewTimer(10 * time.Minute)
+
+	fired := c.AdvanceTo((time.Time{}).Add(5 * time.Minute))
+	if fired != 0 {
+		t.Fatalf("Unexpected fired count, expected=0, actual=%d", fired)
+	}
+
+	fired = c.AdvanceTo((time.Time{}).Add(time.Minute))
+	if fired != 0 {
+		t.Fatalf("Unexpected fired count, expected=0, actual=%d", fired)
+	}
+	expectedNow := (time.Time{}).Add(5 * time.Minute)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+
+	fired = c.AdvanceTo((time.Time{}).Add(time.Hour))
+	if fired != 1 {
+		t.Fatalf("Unexpected fired count, expected=1, actual=%d", fired)
+	}
+	<-timer.Chan()
+
+	expectedNow = (time.Time{}).Add(time.Hour)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+}
+
+func TestFakeClockAdvanceToNext(t *testing.T) {
+	c := clock.NewFakeClock()
+
+	if fired, ok := c.AdvanceToNext(); ok || fired != 0 {
+		t.Fatalf("Unexpected AdvanceToNext result, fired=%d, ok=%t", fired, ok)
+	}
+
+	c.NewTimer(10 * time.Minute)
+	c.NewTimer(time.Minute)
+	c.NewTimer(time.Minute)
+
+	fired, ok := c.AdvanceToNext()
+	if !ok || fired != 2 {
+		t.Fatalf("Unexpected AdvanceToNext result, fired=%d, ok=%t", fired, ok)
+	}
+	expectedNow := (time.Time{}).Add(time.Minute)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+
+	fired, ok = c.AdvanceToNext()
+	if !ok || fired != 1 {
+		t.Fatalf("Unexpected AdvanceToNext result, fired=%d, ok=%t", fired, ok)
+	}
+	expectedNow = (time.Time{}).Add(10 * time.Minute)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+}
+
+func TestFakeClockAdvanceToNextNonPositiveDuration(t *testing.T) {
+	c := clock.NewFakeClock()
+	c.Advance(time.Hour + time.Second)
+
+	timer := c.NewTimer(-time.Minute)
+
+	fired, ok := c.AdvanceToNext()
+	if !ok || fired != 1 {
+		t.Fatalf("Unexpected AdvanceToNext result, fired=%d, ok=%t", fired, ok)
+	}
+	expectedNow := (time.Time{}).Add(time.Hour + time.Second)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+	if actualTime := <-timer.Chan(); actualTime != expectedNow {
+		t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedNow, actualTime)
+	}
+}
+
+func TestFakeClockRunUntilIdle(t *testing.T) {
+	t.Run("timers", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		for i := 1; i <= 5; i++ {
+			c.NewTimer(time.Duration(i) * time.Minute)
+		}
+
+		steps, idle := c.RunUntilIdle(100)
+		if !idle || steps != 5 {
+			t.Fatalf("Unexpected RunUntilIdle result, steps=%d, idle=%t", steps, idle)
+		}
+		expectedNow := (time.Time{}).Add(5 * time.Minute)
+		if now := c.Now(); now != expectedNow {
+			t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+		}
+	})
+
+	t.Run("ticker", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		c.NewTicker(time.Minute)
+
+		steps, idle := c.RunUntilIdle(10)
+		if idle || steps != 10 {
+			t.Fatalf("Unexpected RunUntilIdle result, steps=%d, idle=%t", steps, idle)
+		}
+		expectedNow := (time.Time{}).Add(10 * time.Minute)
+		if now := c.Now(); now != expectedNow {
+			t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+		}
+	})
+}
+
+func TestFakeClockSet(t *testing.T) {
+	t.Run("backward", func(t *testing.T) {
+		initialTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := clock.NewFakeClockAt(initialTime)
+		timer := c.NewTimer(10 * time.Minute)
+
+		c.Advance(5 * time.Minute)
+		before := c.Now()
+
+		c.Set(initialTime.Add(-time.Hour))
+		if now := c.Now(); !now.Before(before) {
+			t.Fatalf("Expected now=%s to be before %s", now, before)
+		}
+
+		c.Advance(4 * time.Minute)
+		select {
+		case <-timer.Chan():
+			t.Fatal("Unexpected timer's channel receive")
+		default:
+		}
+
+		c.Advance(time.Minute)
+		expectedTime := initialTime.Add(-time.Hour).Add(5 * time.Minute)
+		select {
+		case actualTime := <-timer.Chan():
+			if expectedTime != actualTime {
+				t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+			}
+		default:
+			t.Fatal("Expected receive from the timer's channel")
+		}
+	})
+
+	t.Run("forward", func(t *testing.T) {
+		initialTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := clock.NewFakeClockAt(initialTime)
+		timer := c.NewTimer(10 * time.Minute)
+		ticker := c.NewTicker(time.Minute)
+
+		c.Set(initialTime.Add(24 * time.Hour))
+		if now := c.Now(); now != initialTime.Add(24*time.Hour) {
+			t.Fatalf("unexpected now result, expected: %s, actual: %s", initialTime.Add(24*time.Hour), now)
+		}
+
+		select {
+		case <-timer.Chan():
+			t.Fatal("Unexpected timer's channel receive")
+		case <-ticker.Chan():
+			t.Fatal("Unexpected ticker's channel receive")
+		default:
+		}
+
+		c.Advance(time.Minute)
+		expectedTime := initialTime.Add(24 * time.Hour).Add(time.Minute)
+		if actualTime := <-ticker.Chan(); expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the ticker's channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+	})
+}
+
+func TestFakeClockMonotonicTime(t *testing.T) {
+	initialTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClockAt(initialTime)
+
+	start := c.Now()
+	c.Advance(time.Minute)
+	c.Skew(-time.Hour)
+	c.Advance(time.Second)
+
+	expectedNow := initialTime.Add(-time.Hour).Add(time.Minute + time.Second)
+	if now := c.Now(); now != expectedNow {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+	}
+
+	expectedSince := time.Minute + time.Second
+	if actualSince := c.Since(start); expectedSince != actualSince {
+		t.Fatalf("Unexpected Since result value, expected=%s, actual=%s", expectedSince, actualSince)
+	}
+
+	deadline := c.Now().Add(30 * time.Minute)
+	c.Advance(time.Minute)
+
+	expectedUntil := 29 * time.Minute
+	if actualUntil := c.Until(deadline); expectedUntil != actualUntil {
+		t.Fatalf("Unexpected Until result value, expected=%s, actual=%s", expectedUntil, actualUntil)
+	}
+
+	c.Set(initialTime.Add(24 * time.Hour))
+
+	expectedSince = 2*time.Minute + time.Second
+	if actualSince := c.Since(start); expectedSince != actualSince {
+		t.Fatalf("Unexpected Since result value, expected=%s, actual=%s", expectedSince, actualSince)
+	}
+
+	c.Advance(time.Minute)
+	expectedSince = time.Minute
+	if actualSince := c.Since(initialTime.Add(24 * time.Hour)); expectedSince != actualSince {
+		t.Fatalf("Unexpected Since result value, expected=%s, actual=%s", expectedSince, actualSince)
+	}
+}
+
+func TestFakeClockMonotonicTimeBackwardJump(t *testing.T) {
+	initialTime := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClockAt(initialTime)
+
+	start := c.Now()
+	c.Advance(time.Minute)
+	c.Skew(-time.Hour)
+
+	// The wall time passes over the start again.
+	c.Advance(30 * time.Minute)
+	repeated := c.Now()
+	timer := c.NewTimer(28 * time.Minute)
+	c.Advance(29 * time.Minute)
+	tick := <-timer.Chan()
+	c.Advance(time.Hour + time.Minute)
+
+	cases := []struct {
+		name     string
+		t        time.Time
+		expected time.Duration
+	}{
+		{"observed before the jump", start, 2*time.Hour + time.Minute},
+		{"observed after the jump", repeated, time.Hour + 30*time.Minute},
+		{"received after the jump", tick, time.Hour + 2*time.Minute},
+	}
+	for _, tc := range cases {
+		if actualSince := c.Since(tc.t); tc.expected != actualSince {
+			t.Fatalf("Unexpected Since result value for the time %s, expected=%s, actual=%s", tc.name, tc.expected, actualSince)
+		}
+	}
+}
+
+func TestFakeClockMonotonicTimeManyJumps(t *testing.T) {
+	c := clock.NewFakeClock()
+
+	for i := 0; i < 1000; i++ {
+		c.Advance(time.Second)
+		c.Skew(-time.Second)
+	}
+	start := c.Now()
+	c.Advance(time.Minute)
+	c.Skew(-time.Hour)
+	c.Advance(2 * time.Hour)
+
+	expectedSince := 2*time.Hour + time.Minute
+	if actualSince := c.Since(start); expectedSince != actualSince {
+		t.Fatalf("Unexpected Since result value, expected=%s, actual=%s", expectedSince, actualSince)
+	}
+}
+
+func TestFakeClockSyncCallbacks(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+
+	var calls []time.Duration
+	var nows []time.Time
+	for _, d := range []time.Duration{10 * time.Minute, time.Minute, 5 * time.Minute, time.Minute} {
+		d := d
+		c.AfterFunc(d, func() {
+			calls = append(calls, d)
+			nows = append(nows, c.Now())
+		})
+	}
+	timer := c.AfterFunc(time.Minute, func() {
+		calls = append(calls, 0)
+		nows = append(nows, c.Now())
+	})
+	timer.Reset(2 * time.Minute)
+
+	c.Advance(time.Hour)
+
+	expectedCalls := []time.Duration{time.Minute, time.Minute, 0, 5 * time.Minute, 10 * time.Minute}
+	expectedNows := []time.Duration{time.Minute, time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute}
+	if len(calls) != len(expectedCalls) {
+		t.Fatalf("Unexpected callbacks count, expected=%d, actual=%d", len(expectedCalls), len(calls))
+	}
+	for i := range expectedCalls {
+		if expectedCalls[i] != calls[i] {
+			t.Fatalf("Unexpected callbacks order, expected=%v, actual=%v", expectedCalls, calls)
+		}
+		expectedNow := (time.Time{}).Add(expectedNows[i])
+		if expectedNow != nows[i] {
+			t.Fatalf("Unexpected now in the callback, expected=%s, actual=%s", expectedNow, nows[i])
+		}
+	}
+}
+
+func TestFakeClockSyncCallbacksRearm(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+
+	calls := 0
+	var timer clock.Timer
+	timer = c.AfterFunc(time.Minute, func() {
+		calls++
+		timer.Reset(time.Minute)
+	})
+
+	c.Advance(10 * time.Minute)
+	if calls != 10 {
+		t.Fatalf("Unexpected callbacks count, expected=10, actual=%d", calls)
+	}
+}
+
+func TestFakeClockWaitCallbacks(t *testing.T) {
+	t.Run("callbacks finished", func(t *testing.T) {
+		c := clock.NewFakeClock()
+
+		mu := sync.Mutex{}
+		calls := 0
+		for i := 1; i <= 10; i++ {
+			c.AfterFunc(time.Duration(i)*time.Minute, func() {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+			})
+		}
+
+		c.Advance(5 * time.Minute)
+		if err := c.WaitCallbacks(context.Background()); err != nil {
+			t.Fatalf("Unexpected WaitCallbacks error: %s", err)
+		}
+		if n := c.RunningCallbacks(); n != 0 {
+			t.Fatalf("Unexpected running callbacks count, expected=0, actual=%d", n)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if calls != 5 {
+			t.Fatalf("Unexpected callbacks count, expected=5, actual=%d", calls)
+		}
+	})
+
+	t.Run("callback is stuck", func(t *testing.T) {
+		c := clock.NewFakeClock()
+
+		releaseCh := make(chan struct{})
+		c.AfterFunc(time.Minute, func() {
+			<-releaseCh
+		})
+		c.Advance(time.Minute)
+
+		if n := c.RunningCallbacks(); n != 1 {
+			t.Fatalf("Unexpected running callbacks count, expected=1, actual=%d", n)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := c.WaitCallbacks(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("Unexpected WaitCallbacks error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+		}
+
+		close(releaseCh)
+		if err := c.WaitCallbacks(context.Background()); err != nil {
+			t.Fatalf("Unexpected WaitCallbacks error: %s", err)
+		}
+	})
+}
+
+func TestFakeClockAssertNoLeaks(t *testing.T) {
+	t.Run("no leaks", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		r := testrecorder.New(t)
+		c.AssertNoLeaks(r, "background")
+
+		timer := c.NewTimer(time.Minute)
+		ticker := c.NewTicker(time.Minute)
+		c.Labeled("background").NewTicker(time.Minute)
+		timer.Stop()
+		ticker.Stop()
+
+		r.RunCleanups()
+		if failures := r.Failures(); len(failures) != 0 {
+			t.Fatalf("Unexpected failures: %s", failures)
+		}
+	})
+
+	t.Run("leaks", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		r := testrecorder.New(t)
+		c.AssertNoLeaks(r, "background")
+
+		c.NewTicker(time.Minute)
+		c.Labeled("background").NewTicker(time.Minute)
+		c.Labeled("worker").NewTimer(time.Hour)
+
+		r.RunCleanups()
+		failures := r.Failures()
+		if len(failures) != 2 {
+			t.Fatalf("Unexpected failures count, expected=2, actual=%d: %s", len(failures), failures)
+		}
+		for _, expected := range []string{"leaked ticker: duration=1m0s", "leaked timer \"worker\": duration=1h0m0s"} {
+			found := false
+			for _, msg := range failures {
+				if strings.HasPrefix(msg, expected) && strings.Contains(msg, "clock_test.TestFakeClockAssertNoLeaks") {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("Failure %q with the creation stack not found in %s", expected, failures)
+			}
+		}
+	})
+}
@@ -0,0 +1,198 @@
+// Package clocktest provides the fake clock bound to a test.
+// It fails the test instead of hanging and checks for leaked timers on the test cleanup.
+package clocktest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// DefaultTimeout is the default real time limit of the blocking waits.
+const DefaultTimeout = 10 * time.Second
+
+// Option configures the test clock.
+type Option func(*config)
+
+// config is the test clock's configuration.
+type config struct {
+	start         time.Time
+	timeout       time.Duration
+	clockOpts     []clock.Option
+	ignoredLabels []string
+	verbose       bool
+}
+
+// Start sets the initial clock's time.
+// The zero time is used by default.
+func Start(t time.Time) Option {
+	return func(cfg *config) {
+		cfg.start = t
+	}
+}
+
+// Timeout sets the real time limit of the blocking waits.
+// DefaultTimeout is used by default.
+func Timeout(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = d
+	}
+}
+
+// ClockOptions passes the options to the underlying fake clock.
+func ClockOptions(opts ...clock.Option) Option {
+	return func(cfg *config) {
+		cfg.clockOpts = append(cfg.clockOpts, opts...)
+	}
+}
+
+// IgnoreLabels allows waiters with the specified labels
+// to stay active at the test cleanup.
+func IgnoreLabels(labels ...string) Option {
+	return func(cfg *config) {
+		cfg.ignoredLabels = append(cfg.ignoredLabels, labels...)
+	}
+}
+
+// Verbose makes the clock log every waiter event through the test's Logf.
+func Verbose() Option {
+	return func(cfg *config) {
+		cfg.verbose = true
+	}
+}
+
+// Clock is the fake clock bound to the test.
+// Its blocking waits fail the test after the timeout instead of hanging,
+// so they must be called from the test's goroutine.
+type Clock struct {
+	clock.FakeClock
+
+	t       testing.TB
+	timeout time.Duration
+}
+
+// New returns a new fake clock bound to the specified test.
+// At the test cleanup it fails the test if there are AfterFunc callbacks
+// that don't finish within the timeout or if there are leaked timers/tickers/sleepers.
+func New(t testing.TB, opts ...Option) *Clock {
+	t.Helper()
+
+	cfg := config{
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c := &Clock{
+		FakeClock: clock.NewFakeClockAt(cfg.start, cfg.clockOpts...),
+		t:         t,
+		timeout:   cfg.timeout,
+	}
+
+	// Cleanups are called in the reverse order,
+	// so the callbacks are waited for before checking for leaks.
+	c.AssertNoLeaks(t, cfg.ignoredLabels...)
+	t.Cleanup(c.checkCallbacks)
+	if cfg.verbose {
+		c.logEvents()
+	}
+
+	return c
+}
+
+// BlockUntil waits for the specified count of active timers/tickers/sleepers.
+// The test fails if the count isn't reached within the timeout.
+func (c *Clock) BlockUntil(n int) {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.BlockUntilContext(ctx, n); err != nil {
+		c.fail("BlockUntil(%d) timed out after %s", n, c.timeout)
+	}
+}
+
+// BlockUntilKind waits for the specified count of active waiters of the specified kind.
+// The test fails if the count isn't reached within the timeout.
+func (c *Clock) BlockUntilKind(kind clock.WaiterKind, n int) {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.BlockUntilKindContext(ctx, kind, n); err != nil {
+		c.fail("BlockUntilKind(%s, %d) timed out after %s", kind, n, c.timeout)
+	}
+}
+
+// BlockUntilLabel waits for an active waiter with the specified label.
+// The test fails if there is no such waiter within the timeout.
+func (c *Clock) BlockUntilLabel(label string) {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.BlockUntilLabelContext(ctx, label); err != nil {
+		c.fail("BlockUntilLabel(%q) timed out after %s", label, c.timeout)
+	}
+}
+
+// WaitCallbacks waits until all triggered AfterFunc callbacks are finished.
+// The test fails if they aren't finished within the timeout.
+func (c *Clock) WaitCallbacks() {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.FakeClock.WaitCallbacks(ctx); err != nil {
+		c.fail("WaitCallbacks timed out after %s, %d callbacks are running", c.timeout, c.RunningCallbacks())
+	}
+}
+
+// fail fails the test with the message followed by the list of active waiters.
+func (c *Clock) fail(format string, args ...interface{}) {
+	c.t.Helper()
+	c.t.Fatalf("%s\n%s", fmt.Sprintf(format, args...), c.DescribeWaiters())
+}
+
+// checkCallbacks fails the test if AfterFunc callbacks don't finish within the timeout.
+func (c *Clock) checkCallbacks() {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.FakeClock.WaitCallbacks(ctx); err != nil {
+		c.t.Errorf("%d AfterFunc callbacks are still running after %s", c.RunningCallbacks(), c.timeout)
+	}
+}
+
+// logEvents logs every waiter event through the test's Logf until the test cleanup.
+func (c *Clock) logEvents() {
+	events, cancel := c.Subscribe()
+	doneCh := make(chan struct{})
+
+	go func() {
+		defer close(doneCh)
+
+		for e := range events {
+			if e.Label != "" {
+				c.t.Logf("clock: %s %q %s: duration=%s trigger=%s", e.Kind, e.Label, e.Action, e.Duration, e.TriggerTime)
+			} else {
+				c.t.Logf("clock: %s %s: duration=%s trigger=%s", e.Kind, e.Action, e.Duration, e.TriggerTime)
+			}
+		}
+	}()
+
+	c.t.Cleanup(func() {
+		cancel()
+		<-doneCh
+	})
+}
@@ -0,0 +1,140 @@
+package clocktest_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/clocktest"
+	"github.com/LopatkinEvgeniy/clock/internal/testrecorder"
+)
+
+func TestNew(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clocktest.New(t, clocktest.Start(start), clocktest.ClockOptions(clock.WithSyncCallbacks()))
+
+	if now := c.Now(); now != start {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", start, now)
+	}
+
+	doneCh := make(chan struct{})
+	go func() {
+		c.Sleep(time.Minute)
+		close(doneCh)
+	}()
+	c.BlockUntilKind(clock.KindSleeper, 1)
+	c.Advance(time.Minute)
+	<-doneCh
+
+	called := false
+	c.AfterFunc(time.Minute, func() {
+		called = true
+	})
+	c.Advance(time.Minute)
+	if !called {
+		t.Fatal("Expected synchronous callback call")
+	}
+}
+
+func TestBlockUntilTimeout(t *testing.T) {
+	r := testrecorder.New(t)
+	c := clocktest.New(r, clocktest.Timeout(10*time.Millisecond))
+	c.Labeled("worker").NewTimer(time.Minute)
+
+	c.BlockUntil(2)
+	c.BlockUntilLabel("retry")
+	c.BlockUntilKind(clock.KindTicker, 1)
+
+	failures := r.Failures()
+	if len(failures) != 3 {
+		t.Fatalf("Unexpected failures count, expected=3, actual=%d: %s", len(failures), failures)
+	}
+	for _, msg := range failures {
+		if !strings.Contains(msg, `timer "worker": duration=1m0s`) {
+			t.Fatalf("Failure message %q doesn't list the active waiters", msg)
+		}
+	}
+}
+
+func TestCleanupChecks(t *testing.T) {
+	r := testrecorder.New(t)
+	c := clocktest.New(r, clocktest.Timeout(10*time.Millisecond), clocktest.IgnoreLabels("background"))
+
+	c.NewTicker(time.Minute)
+	c.Labeled("background").NewTicker(time.Minute)
+
+	releaseCh := make(chan struct{})
+	defer close(releaseCh)
+	c.AfterFunc(time.Second, func() {
+		<-releaseCh
+	})
+	c.Advance(time.Second)
+
+	r.RunCleanups()
+
+	failures := r.Failures()
+	if len(failures) != 2 {
+		t.Fatalf("Unexpected failures count, expected=2, actual=%d: %s", len(failures), failures)
+	}
+	if !strings.Contains(failures[0], "1 AfterFunc callbacks are still running") {
+		t.Fatalf("Unexpected failure: %s", failures[0])
+	}
+	if !strings.HasPrefix(failures[1], "leaked ticker: duration=1m0s") {
+		t.Fatalf("Unexpected failure: %s", failures[1])
+	}
+}
+
+func TestVerbose(t *testing.T) {
+	r := testrecorder.New(t)
+	c := clocktest.New(r, clocktest.Verbose())
+
+	timer := c.Labeled("retry").NewTimer(time.Minute)
+	timer.Stop()
+	c.NewTimer(time.Second).Stop()
+
+	expected := []string{
+		`clock: timer "retry" registered: duration=1m0s`,
+		`clock: timer "retry" stopped: duration=1m0s`,
+		`clock: timer registered: duration=1s`,
+		`clock: timer stopped: duration=1s`,
+	}
+
+	// Events are logged asynchronously.
+	deadline := time.Now().Add(time.Second)
+	for {
+		n := len(r.Logs())
+		if n >= len(expected) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	r.RunCleanups()
+
+	failures, logs := r.Failures(), r.Logs()
+	if len(failures) != 0 {
+		t.Fatalf("Unexpected failures: %s", failures)
+	}
+	if len(logs) != len(expected) {
+		t.Fatalf("Unexpected logs count, expected=%d, actual=%d: %s", len(expected), len(logs), logs)
+	}
+	for i := range expected {
+		if !strings.HasPrefix(logs[i], expected[i]) {
+			t.Fatalf("Unexpected log, expected prefix=%q, actual=%q", expected[i], logs[i])
+		}
+	}
+}
+
+func TestLabeled(t *testing.T) {
+	c := clocktest.New(t)
+
+	var cl clock.Clock = c
+	timer := clock.Labeled(cl, "retry").NewTimer(time.Hour)
+
+	c.BlockUntilLabel("retry")
+	if fired := c.FireLabel("retry"); fired != 1 {
+		t.Fatalf("Unexpected fired count, expected=1, actual=%d", fired)
+	}
+	<-timer.Chan()
+}
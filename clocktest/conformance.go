@@ -0,0 +1,365 @@
+package clocktest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// Harness is a clock implementation under the conformance test.
+type Harness struct {
+	// Clock is the tested clock.
+	Clock clock.Clock
+	// Advance lets d of the clock's time pass.
+	Advance func(d time.Duration)
+	// BlockUntil waits for the specified count of the clock's active waiters.
+	// It's optional, nil means the clock doesn't track its waiters.
+	BlockUntil func(n int)
+}
+
+// Factory creates a new harness for every conformance test case.
+type Factory func(t *testing.T) Harness
+
+// RealFactory creates harnesses for the real clock.
+// Advance just sleeps for the specified duration.
+func RealFactory(t *testing.T) Harness {
+	return Harness{
+		Clock:   clock.NewRealClock(),
+		Advance: time.Sleep,
+	}
+}
+
+// FakeFactory returns the factory of harnesses for the fake clock
+// created with the specified options.
+func FakeFactory(opts ...clock.Option) Factory {
+	return func(t *testing.T) Harness {
+		c := clock.NewFakeClock(opts...)
+		return Harness{
+			Clock:      c,
+			Advance:    c.Advance,
+			BlockUntil: c.BlockUntil,
+		}
+	}
+}
+
+const (
+	// unit is the base duration used by the conformance tests.
+	// It's small enough to keep the real clock's tests fast.
+	unit = 20 * time.Millisecond
+	// notYetDuration is the duration of the timers checked not to fire after a unit.
+	// It leaves the real clock a wide margin for the oversleeping Advance.
+	notYetDuration = 10 * unit
+	// receiveTimeout is the real time limit of the expected channel receives.
+	receiveTimeout = time.Second
+)
+
+// RunConformance checks that the clock created by the factory
+// behaves like the real clock in every Clock, Timer and Ticker method.
+// The checks are independent of Go 1.23 timer channel semantics.
+func RunConformance(t *testing.T, factory Factory) {
+	cases := []struct {
+		name string
+		test func(t *testing.T, h Harness)
+	}{
+		{"Now", testNow},
+		{"SinceUntil", testSinceUntil},
+		{"After", testAfter},
+		{"AfterFunc", testAfterFunc},
+		{"AfterFuncStop", testAfterFuncStop},
+		{"AfterFuncStopFired", testAfterFuncStopFired},
+		{"Sleep", testSleep},
+		{"Tick", testTick},
+		{"TickNonPositive", testTickNonPositive},
+		{"NewTicker", testNewTicker},
+		{"NewTickerPanics", testNewTickerPanics},
+		{"TickerReset", testTickerReset},
+		{"TickerStop", testTickerStop},
+		{"NewTimer", testNewTimer},
+		{"TimerStop", testTimerStop},
+		{"TimerReset", testTimerReset},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, factory(t))
+		})
+	}
+}
+
+// expectReceive receives from the channel or fails the test after receiveTimeout.
+func expectReceive(t *testing.T, ch <-chan time.Time, what string) time.Time {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(receiveTimeout):
+		t.Fatalf("Expected receive from the %s channel", what)
+		return time.Time{}
+	}
+}
+
+// expectNoReceive fails the test if the channel has a value.
+func expectNoReceive(t *testing.T, ch <-chan time.Time, what string) {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		t.Fatalf("Unexpected %s channel receive: %s", what, v)
+	default:
+	}
+}
+
+// expectPanic fails the test if f doesn't panic.
+func expectPanic(t *testing.T, f func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic")
+		}
+	}()
+	f()
+}
+
+func testNow(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	h.Advance(unit)
+
+	if now := h.Clock.Now(); now.Before(start.Add(unit)) {
+		t.Fatalf("Unexpected Now result, expected not before %s, actual=%s", start.Add(unit), now)
+	}
+}
+
+func testSinceUntil(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	h.Advance(unit)
+
+	if since := h.Clock.Since(start); since < unit {
+		t.Fatalf("Unexpected Since result, expected at least %s, actual=%s", unit, since)
+	}
+	if until := h.Clock.Until(start); until > -unit {
+		t.Fatalf("Unexpected Until result, expected at most %s, actual=%s", -unit, until)
+	}
+	if until := h.Clock.Until(h.Clock.Now().Add(time.Hour)); until > time.Hour || until < time.Hour-receiveTimeout {
+		t.Fatalf("Unexpected Until result, expected about %s, actual=%s", time.Hour, until)
+	}
+}
+
+func testAfter(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	ch := h.Clock.After(notYetDuration)
+
+	h.Advance(unit)
+	expectNoReceive(t, ch, "After")
+
+	h.Advance(notYetDuration - unit)
+	if v := expectReceive(t, ch, "After"); v.Before(start.Add(notYetDuration)) {
+		t.Fatalf("Unexpected After value, expected not before %s, actual=%s", start.Add(notYetDuration), v)
+	}
+}
+
+func testAfterFunc(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+
+	mu := sync.Mutex{}
+	called := false
+	timer := h.Clock.AfterFunc(unit, func() {
+		mu.Lock()
+		called = true
+		mu.Unlock()
+	})
+	if timer.Chan() == nil {
+		t.Fatal("Unexpected nil AfterFunc timer's channel")
+	}
+
+	h.Advance(unit)
+
+	v := expectReceive(t, timer.Chan(), "AfterFunc timer's")
+	if v.Before(start.Add(unit)) {
+		t.Fatalf("Unexpected AfterFunc timer's value, expected not before %s, actual=%s", start.Add(unit), v)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !called {
+		t.Fatal("AfterFunc timer's channel received before the callback is called")
+	}
+	if timer.Stop() {
+		t.Fatal("Unexpected Stop result for the fired AfterFunc timer")
+	}
+}
+
+func testAfterFuncStop(t *testing.T, h Harness) {
+	calledCh := make(chan time.Time, 1)
+	timer := h.Clock.AfterFunc(unit, func() {
+		calledCh <- time.Time{}
+	})
+
+	if !timer.Stop() {
+		t.Fatal("Unexpected Stop result for the active AfterFunc timer")
+	}
+	if timer.Stop() {
+		t.Fatal("Unexpected Stop result for the stopped AfterFunc timer")
+	}
+
+	h.Advance(2 * unit)
+	expectNoReceive(t, calledCh, "AfterFunc callback's")
+	expectNoReceive(t, timer.Chan(), "AfterFunc timer's")
+}
+
+func testAfterFuncStopFired(t *testing.T, h Harness) {
+	timer := h.Clock.AfterFunc(unit, func() {})
+
+	h.Advance(unit)
+
+	// The value is sent once the callback returns, it's not received before Stop.
+	deadline := time.Now().Add(receiveTimeout)
+	for len(timer.Chan()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("AfterFunc timer's channel wasn't filled in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if timer.Stop() {
+		t.Fatal("Unexpected Stop result for the fired AfterFunc timer")
+	}
+	expectReceive(t, timer.Chan(), "AfterFunc timer's")
+}
+
+func testSleep(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	doneCh := make(chan time.Time, 1)
+	go func() {
+		h.Clock.Sleep(unit)
+		doneCh <- h.Clock.Now()
+	}()
+
+	if h.BlockUntil != nil {
+		h.BlockUntil(1)
+	}
+	h.Advance(unit)
+
+	if v := expectReceive(t, doneCh, "Sleep done"); v.Before(start.Add(unit)) {
+		t.Fatalf("Sleep returned too early, expected not before %s, actual=%s", start.Add(unit), v)
+	}
+}
+
+func testTick(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	ch := h.Clock.Tick(unit)
+	if ch == nil {
+		t.Fatal("Unexpected nil Tick channel")
+	}
+
+	h.Advance(unit)
+	if v := expectReceive(t, ch, "Tick"); v.Before(start.Add(unit)) {
+		t.Fatalf("Unexpected tick value, expected not before %s, actual=%s", start.Add(unit), v)
+	}
+}
+
+func testTickNonPositive(t *testing.T, h Harness) {
+	if h.Clock.Tick(0) != nil {
+		t.Fatal("Expected nil Tick channel for zero duration")
+	}
+	if h.Clock.Tick(-unit) != nil {
+		t.Fatal("Expected nil Tick channel for negative duration")
+	}
+}
+
+func testNewTicker(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	ticker := h.Clock.NewTicker(unit)
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		h.Advance(unit)
+		if v := expectReceive(t, ticker.Chan(), "ticker's"); v.Before(start.Add(unit)) {
+			t.Fatalf("Unexpected tick value, expected not before %s, actual=%s", start.Add(unit), v)
+		}
+	}
+}
+
+func testNewTickerPanics(t *testing.T, h Harness) {
+	expectPanic(t, func() {
+		h.Clock.NewTicker(0)
+	})
+	expectPanic(t, func() {
+		h.Clock.NewTicker(-unit)
+	})
+}
+
+func testTickerReset(t *testing.T, h Harness) {
+	ticker := h.Clock.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	ticker.Reset(unit)
+	h.Advance(unit)
+	expectReceive(t, ticker.Chan(), "ticker's")
+
+	expectPanic(t, func() {
+		ticker.Reset(0)
+	})
+}
+
+func testTickerStop(t *testing.T, h Harness) {
+	ticker := h.Clock.NewTicker(unit)
+	ticker.Stop()
+	ticker.Stop()
+
+	h.Advance(2 * unit)
+	expectNoReceive(t, ticker.Chan(), "ticker's")
+}
+
+func testNewTimer(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	timer := h.Clock.NewTimer(notYetDuration)
+
+	h.Advance(unit)
+	expectNoReceive(t, timer.Chan(), "timer's")
+
+	h.Advance(notYetDuration - unit)
+	if v := expectReceive(t, timer.Chan(), "timer's"); v.Before(start.Add(notYetDuration)) {
+		t.Fatalf("Unexpected timer's value, expected not before %s, actual=%s", start.Add(notYetDuration), v)
+	}
+}
+
+func testTimerStop(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(unit)
+	if !timer.Stop() {
+		t.Fatal("Unexpected Stop result for the active timer")
+	}
+	if timer.Stop() {
+		t.Fatal("Unexpected Stop result for the stopped timer")
+	}
+
+	h.Advance(2 * unit)
+	expectNoReceive(t, timer.Chan(), "timer's")
+
+	timer = h.Clock.NewTimer(unit)
+	h.Advance(unit)
+	expectReceive(t, timer.Chan(), "timer's")
+	if timer.Stop() {
+		t.Fatal("Unexpected Stop result for the fired timer")
+	}
+}
+
+func testTimerReset(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(time.Hour)
+	if !timer.Reset(unit) {
+		t.Fatal("Unexpected Reset result for the active timer")
+	}
+
+	h.Advance(unit)
+	expectReceive(t, timer.Chan(), "timer's")
+
+	if timer.Reset(unit) {
+		t.Fatal("Unexpected Reset result for the fired timer")
+	}
+	h.Advance(unit)
+	expectReceive(t, timer.Chan(), "timer's")
+}
@@ -0,0 +1,20 @@
+package clocktest_test
+
+import (
+	"testing"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/clocktest"
+)
+
+func TestConformanceRealClock(t *testing.T) {
+	clocktest.RunConformance(t, clocktest.RealFactory)
+}
+
+func TestConformanceFakeClock(t *testing.T) {
+	clocktest.RunConformance(t, clocktest.FakeFactory())
+}
+
+func TestConformanceFakeClockOptions(t *testing.T) {
+	clocktest.RunConformance(t, clocktest.FakeFactory(clock.WithGo123Timers(), clock.WithSyncCallbacks()))
+}
@@ -0,0 +1,133 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithDeadline works like context.WithDeadline,
+// but the deadline is tracked by the specified clock.
+// The real clock simply delegates to the context package.
+func WithDeadline(clock Clock, parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	if _, ok := clock.(realClock); ok {
+		return context.WithDeadline(parent, d)
+	}
+
+	if cur, ok := parent.Deadline(); ok && cur.Before(d) {
+		// The current deadline is already sooner than the new one.
+		return context.WithCancel(parent)
+	}
+
+	cancelCtx, cancel := context.WithCancel(parent)
+	ctx := &clockCtx{
+		Context:  cancelCtx,
+		parent:   parent,
+		cancel:   cancel,
+		deadline: d,
+	}
+
+	dur := clock.Until(d)
+	if dur <= 0 {
+		ctx.expire()
+		return ctx, ctx.stop
+	}
+
+	ctx.mu.Lock()
+	if cancelCtx.Err() == nil {
+		ctx.timer = clock.AfterFunc(dur, ctx.expire)
+	}
+	ctx.mu.Unlock()
+
+	go ctx.watch()
+
+	return ctx, ctx.stop
+}
+
+// WithTimeout works like context.WithTimeout,
+// but the timeout is tracked by the specified clock.
+// The real clock simply delegates to the context package.
+func WithTimeout(clock Clock, parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := clock.(realClock); ok {
+		return context.WithTimeout(parent, timeout)
+	}
+	return WithDeadline(clock, parent, clock.Now().Add(timeout))
+}
+
+// clockCtx is a context with the deadline tracked by the clock's timer.
+type clockCtx struct {
+	context.Context
+
+	parent   context.Context
+	cancel   context.CancelFunc
+	deadline time.Time
+
+	mu    sync.Mutex
+	timer Timer
+	err   error
+}
+
+// Deadline implements context.Context.
+func (c *clockCtx) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+// Err implements context.Context.
+// It returns nil until Done is closed.
+func (c *clockCtx) Err() error {
+	select {
+	case <-c.Done():
+	default:
+		return nil
+	}
+
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return c.Context.Err()
+}
+
+// Value implements context.Context.
+// Values are looked up in the parent context directly,
+// so derived contexts observe clockCtx's own Err.
+func (c *clockCtx) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// expire cancels the context with context.DeadlineExceeded error.
+func (c *clockCtx) expire() {
+	c.mu.Lock()
+	if c.err == nil && c.Context.Err() == nil {
+		c.err = context.DeadlineExceeded
+	}
+	c.mu.Unlock()
+
+	c.cancel()
+}
+
+// stop cancels the context and releases the clock's timer.
+func (c *clockCtx) stop() {
+	c.stopTimer()
+	c.cancel()
+}
+
+// watch releases the clock's timer once the context is done,
+// so the timer doesn't outlive the canceled parent.
+func (c *clockCtx) watch() {
+	<-c.Done()
+	c.stopTimer()
+}
+
+// stopTimer stops the clock's timer.
+func (c *clockCtx) stopTimer() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+}
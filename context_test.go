@@ -0,0 +1,139 @@
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("fake clock", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		ctx, cancel := clock.WithTimeout(c, context.Background(), time.Minute)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		expectedDeadline := (time.Time{}).Add(time.Minute)
+		if !ok || deadline != expectedDeadline {
+			t.Fatalf("Unexpected deadline, expected=%s, actual=%s", expectedDeadline, deadline)
+		}
+
+		c.Advance(59 * time.Second)
+		select {
+		case <-ctx.Done():
+			t.Fatal("Premature context cancellation")
+		default:
+		}
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("Unexpected context error: %s", err)
+		}
+
+		c.Advance(time.Second)
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.DeadlineExceeded {
+			t.Fatalf("Unexpected context error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("real clock", func(t *testing.T) {
+		ctx, cancel := clock.WithTimeout(clock.NewRealClock(), context.Background(), time.Millisecond)
+		defer cancel()
+
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.DeadlineExceeded {
+			t.Fatalf("Unexpected context error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestWithDeadline(t *testing.T) {
+	t.Run("cancel", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		ctx, cancel := clock.WithDeadline(c, context.Background(), (time.Time{}).Add(time.Hour))
+
+		cancel()
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.Canceled {
+			t.Fatalf("Unexpected context error, expected=%v, actual=%v", context.Canceled, err)
+		}
+		if n := c.WaitersCount(); n != 0 {
+			t.Fatalf("Unexpected waiters count, expected=0, actual=%d", n)
+		}
+	})
+
+	t.Run("parent cancelled", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := clock.WithDeadline(c, parent, (time.Time{}).Add(time.Hour))
+		defer cancel()
+
+		cancelParent()
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.Canceled {
+			t.Fatalf("Unexpected context error, expected=%v, actual=%v", context.Canceled, err)
+		}
+
+		waitCtx, cancelWait := context.WithTimeout(context.Background(), time.Second)
+		defer cancelWait()
+		if err := c.BlockUntilContext(waitCtx, 0); err != nil {
+			t.Fatalf("Context's timer wasn't released after the parent cancellation: %s", err)
+		}
+	})
+
+	t.Run("err is set with done", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			c := clock.NewFakeClock()
+			ctx, cancel := clock.WithTimeout(c, context.Background(), time.Minute)
+
+			checked := make(chan struct{})
+			go func() {
+				defer close(checked)
+				for ctx.Err() == nil {
+				}
+				select {
+				case <-ctx.Done():
+				default:
+					t.Error("Unexpected context error before done")
+				}
+			}()
+
+			c.Advance(time.Minute)
+			<-checked
+			cancel()
+		}
+	})
+
+	t.Run("deadline in the past", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		c.Advance(time.Hour)
+		ctx, cancel := clock.WithDeadline(c, context.Background(), (time.Time{}).Add(time.Minute))
+		defer cancel()
+
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.DeadlineExceeded {
+			t.Fatalf("Unexpected context error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("parent deadline is sooner", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		parent, cancelParent := clock.WithTimeout(c, context.Background(), time.Minute)
+		defer cancelParent()
+		ctx, cancel := clock.WithTimeout(c, parent, time.Hour)
+		defer cancel()
+
+		deadline, _ := ctx.Deadline()
+		expectedDeadline := (time.Time{}).Add(time.Minute)
+		if deadline != expectedDeadline {
+			t.Fatalf("Unexpected deadline, expected=%s, actual=%s", expectedDeadline, deadline)
+		}
+
+		c.Advance(time.Minute)
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.DeadlineExceeded {
+			t.Fatalf("Unexpected context error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+		}
+	})
+}
@@ -1,158 +1,651 @@
 package clock
 
 import (
+	"container/heap"
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 // internalTimer is an internal representaion
 // of mock timers, tickers and sleepers.
+// The trigger time is measured by the clock's monotonic time.
 type internalTimer struct {
 	clock       *internalClock
 	ch          chan time.Time
 	triggerTime time.Time
 	callback    func()
-	isTicker    bool
+	kind        WaiterKind
+	label       string
+	catchUp     bool
 	duration    time.Duration
+	seq         uint64
+	index       int
+	stopped     chan struct{}
+	stack       []uintptr
+}
+
+// before reports whether t should be triggered before the other timer.
+func (t *internalTimer) before(other *internalTimer) bool {
+	if t.triggerTime.Equal(other.triggerTime) {
+		return t.seq < other.seq
+	}
+	return t.triggerTime.Before(other.triggerTime)
+}
+
+// isActive reports whether the timer is registered in the clock.
+// Lock required.
+func (t *internalTimer) isActive() bool {
+	return t.index >= 0
+}
+
+// isTicker reports whether the timer is a ticker.
+func (t *internalTimer) isTicker() bool {
+	return t.kind == KindTicker || t.kind == KindTick
+}
+
+// info returns the public description of the timer.
+// Lock required.
+func (t *internalTimer) info() WaiterInfo {
+	return WaiterInfo{
+		Kind:        t.kind,
+		Label:       t.label,
+		Duration:    t.duration,
+		TriggerTime: t.clock.wallTime(t.triggerTime),
+		CallSite:    callSite(t.stack),
+		stack:       t.stack,
+	}
 }
 
 // internalClock in an internal implementation
 // of base mock clock functionality.
-// internalClock has it's own current time value.
+// internalClock has it's own monotonic and wall time values.
+// The monotonic time only moves forward, timers are driven by it.
+// The wall time is the monotonic time shifted by the wall offset,
+// it's changed independently to model wall clock jumps.
 // All active timers/tickers/waiters are registered here.
 type internalClock struct {
-	mu     sync.Mutex
-	now    time.Time
-	timers map[*internalTimer]struct{}
+	advanceMu        sync.Mutex
+	mu               sync.Mutex
+	mono             time.Time
+	wallOffset       time.Duration
+	wallEpochs       []wallEpoch
+	maxWall          time.Time
+	overlaps         map[wallKey]time.Time
+	overlapKeys      []wallKey
+	timers           timerHeap
+	kindCounts       map[WaiterKind]int
+	labelCounts      map[string]int
+	seq              uint64
+	tickerCatchUp    bool
+	catchUpLabels    map[string]bool
+	syncCallbacks    bool
+	syncTimerChans   bool
+	creationStacks   bool
+	runningCallbacks int
+	changed          chan struct{}
+	subscribers      map[*subscriber]struct{}
+}
+
+// wallEpoch is a period of the monotonic time with the constant wall offset.
+// It starts at the specified monotonic time and lasts until the next epoch's start.
+type wallEpoch struct {
+	monoStart  time.Time
+	wallOffset time.Duration
+}
+
+// maxWallEpochs is the maximum count of remembered wall epochs.
+const maxWallEpochs = 64
+
+// maxOverlaps is the maximum count of remembered observations
+// of the wall times repeated after the wall time moved backward.
+const maxOverlaps = 1024
+
+// wallKey is the wall time's comparable representation.
+type wallKey struct {
+	sec  int64
+	nsec int
+}
+
+// newWallKey returns the key of the wall time.
+func newWallKey(t time.Time) wallKey {
+	return wallKey{sec: t.Unix(), nsec: t.Nanosecond()}
+}
+
+// enableCreationStacks makes the clock capture the creation stacks
+// of the timers created from now on.
+func (c *internalClock) enableCreationStacks() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.creationStacks = true
 }
 
 // newInternalClock creates a new initialized internalClock instance.
 func newInternalClock(t time.Time) *internalClock {
 	return &internalClock{
-		now:    t,
-		timers: map[*internalTimer]struct{}{},
+		mono:        t,
+		wallEpochs:  []wallEpoch{{monoStart: t}},
+		overlaps:    map[wallKey]time.Time{},
+		kindCounts:  map[WaiterKind]int{},
+		labelCounts: map[string]int{},
+		changed:     make(chan struct{}),
+		subscribers: map[*subscriber]struct{}{},
 	}
 }
 
-// getCurrentTime returns an internalClock's current time value.
+// getCurrentTime returns an internalClock's current wall time value.
 func (c *internalClock) getCurrentTime() time.Time {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	return c.now
+	return c.observedWallTime(c.mono)
+}
+
+// since returns the monotonic time elapsed since the specified wall time.
+// The time is expected to be observed in the past.
+func (c *internalClock) since(t time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.mono.Sub(c.monoTime(t))
+}
+
+// until returns the monotonic time left until the specified wall time.
+// The time is expected to be in the future of the current wall epoch.
+func (c *internalClock) until(t time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	mono := t.Add(-c.wallOffset)
+	if mono.Before(c.mono) {
+		mono = c.monoTime(t)
+	}
+
+	return mono.Sub(c.mono)
+}
+
+// wallTime converts the monotonic time to the current wall time.
+// Lock required.
+func (c *internalClock) wallTime(mono time.Time) time.Time {
+	return mono.Add(c.wallOffset)
+}
+
+// observedWallTime converts the monotonic time to the wall time given to the clock's user.
+// The wall times repeated after the wall time moved backward are remembered,
+// so monoTime can tell them from the same wall times observed before.
+// Lock required.
+func (c *internalClock) observedWallTime(mono time.Time) time.Time {
+	wall := c.wallTime(mono)
+	if len(c.wallEpochs) == 1 || wall.After(c.maxWall) {
+		return wall
+	}
+
+	key := newWallKey(wall)
+	if _, ok := c.overlaps[key]; !ok {
+		if len(c.overlapKeys) == maxOverlaps {
+			delete(c.overlaps, c.overlapKeys[0])
+			c.overlapKeys = append(c.overlapKeys[:0], c.overlapKeys[1:]...)
+		}
+		c.overlapKeys = append(c.overlapKeys, key)
+	}
+	c.overlaps[key] = mono
+
+	return wall
+}
+
+// monoTime converts the wall time to the monotonic time.
+// The remembered repeated wall times are converted exactly.
+// Otherwise the wall time is converted with the offset of the earliest wall epoch
+// that passed over it, as the later epochs' observations would be remembered.
+// The current wall offset is used for the times that no epoch passed over.
+// Lock required.
+func (c *internalClock) monoTime(wall time.Time) time.Time {
+	if mono, ok := c.overlaps[newWallKey(wall)]; ok {
+		return mono
+	}
+
+	for i, e := range c.wallEpochs {
+		monoEnd := c.mono
+		if i+1 < len(c.wallEpochs) {
+			monoEnd = c.wallEpochs[i+1].monoStart
+		}
+
+		mono := wall.Add(-e.wallOffset)
+		if !mono.Before(e.monoStart) && !mono.After(monoEnd) {
+			return mono
+		}
+	}
+
+	return wall.Add(-c.wallOffset)
 }
 
 // moveTimeForward adds specified duration
 // to the current internalClock's time.
 // It will affect all registered timers.
-func (c *internalClock) moveTimeForward(d time.Duration) {
+// It returns the number of triggered timers.
+func (c *internalClock) moveTimeForward(d time.Duration) int {
+	c.advanceMu.Lock()
+	defer c.advanceMu.Unlock()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.now = c.now.Add(d)
+	return c.advance(c.mono.Add(d))
+}
 
-	for t := range c.timers {
-		if t.triggerTime.After(c.now) {
+// moveTimeTo moves the current internalClock's time forward
+// until the wall time becomes the specified time.
+// It does nothing if the specified time is before the current wall time.
+// It returns the number of triggered timers.
+func (c *internalClock) moveTimeTo(t time.Time) int {
+	c.advanceMu.Lock()
+	defer c.advanceMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target := t.Add(-c.wallOffset)
+	if target.Before(c.mono) {
+		return 0
+	}
+	return c.advance(target)
+}
+
+// moveTimeToNext sets the current internalClock's time
+// to the earliest registered timer's trigger time.
+// It returns the number of triggered timers
+// and false if there are no registered timers.
+func (c *internalClock) moveTimeToNext() (int, bool) {
+	c.advanceMu.Lock()
+	defer c.advanceMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := c.timers.peek()
+	if t == nil {
+		return 0, false
+	}
+
+	target := t.triggerTime
+	if target.Before(c.mono) {
+		target = c.mono
+	}
+	return c.advance(target), true
+}
+
+// setTime sets the current internalClock's wall time to the specified time.
+// The wall time can be moved both forward and backward.
+// The monotonic time isn't changed, so none of registered timers is affected.
+func (c *internalClock) setTime(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setWallOffset(t.Sub(c.mono))
+}
+
+// skewTime moves the current internalClock's wall time by the specified duration.
+// The monotonic time isn't changed, so none of registered timers is affected.
+func (c *internalClock) skewTime(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setWallOffset(c.wallOffset + d)
+}
+
+// setWallOffset changes the wall offset starting a new wall epoch.
+// Only the latest maxWallEpochs epochs are remembered.
+// Lock required.
+func (c *internalClock) setWallOffset(offset time.Duration) {
+	if wall := c.wallTime(c.mono); len(c.wallEpochs) == 1 || wall.After(c.maxWall) {
+		c.maxWall = wall
+	}
+	c.wallOffset = offset
+
+	if len(c.wallEpochs) == maxWallEpochs {
+		c.wallEpochs = append(c.wallEpochs[:0], c.wallEpochs[1:]...)
+	}
+	c.wallEpochs = append(c.wallEpochs, wallEpoch{
+		monoStart:  c.mono,
+		wallOffset: offset,
+	})
+}
+
+// fireLabel triggers all registered waiters with the specified label
+// as if their trigger time is the current time.
+// The next tickers' ticks are scheduled a period after the current time.
+// It returns the number of triggered timers.
+func (c *internalClock) fireLabel(label string) int {
+	c.advanceMu.Lock()
+	defer c.advanceMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var timers []*internalTimer
+	for _, t := range c.timers {
+		if t.label == label {
+			timers = append(timers, t)
+		}
+	}
+	sort.Slice(timers, func(i, j int) bool {
+		return timers[i].before(timers[j])
+	})
+
+	fired := 0
+	for _, t := range timers {
+		// The lock may be released while triggering, so the timer could be stopped.
+		if !t.isActive() || t.label != label {
 			continue
 		}
 
-		if t.isTicker {
-			c.triggerTicker(t)
+		t.triggerTime = c.mono
+		heap.Fix(&c.timers, t.index)
+		fired++
+
+		if t.isTicker() && t.catchUp {
+			c.triggerCatchUpTicker(t)
+		} else if t.isTicker() {
+			c.triggerTicker(t, c.mono)
 		} else {
 			c.triggerTimer(t)
 		}
 	}
+
+	return fired
+}
+
+// advance moves the current monotonic time forward to the target time.
+// Due timers are triggered in chronological order,
+// timers with the same trigger time are triggered in creation order.
+// The current time is set to the timer's trigger time while it's being triggered.
+// It returns the number of triggered timers.
+// Both advance and regular locks required, concurrent calls are serialized this way.
+func (c *internalClock) advance(target time.Time) int {
+	fired := 0
+
+	for {
+		t := c.nextTimer(target)
+		if t == nil {
+			break
+		}
+
+		c.mono = t.triggerTime
+		fired++
+
+		if t.isTicker() && t.catchUp {
+			c.triggerCatchUpTicker(t)
+		} else if t.isTicker() {
+			c.triggerTicker(t, target)
+		} else {
+			c.triggerTimer(t)
+		}
+	}
+
+	c.mono = target
+
+	return fired
+}
+
+// nextTimer returns the earliest registered timer
+// which should be triggered not later than the specified time.
+// It returns nil if there is no such timer.
+// Lock required.
+func (c *internalClock) nextTimer(until time.Time) *internalTimer {
+	t := c.timers.peek()
+	if t == nil || t.triggerTime.After(until) {
+		return nil
+	}
+	return t
 }
 
 // triggerTicker triggers specified ticker.
+// The next ticker's trigger time is moved past the until time.
 // Lock required.
-func (c *internalClock) triggerTicker(t *internalTimer) {
+func (c *internalClock) triggerTicker(t *internalTimer, until time.Time) {
 	originalTriggerTime := t.triggerTime
+	c.publish(t, ActionFired)
 
-	for !t.triggerTime.After(c.now) {
-		t.triggerTime = t.triggerTime.Add(t.duration)
+	if !t.triggerTime.After(until) {
+		periods := until.Sub(t.triggerTime)/t.duration + 1
+		t.triggerTime = t.triggerTime.Add(periods * t.duration)
 	}
+	heap.Fix(&c.timers, t.index)
 
 	select {
-	case t.ch <- originalTriggerTime:
+	case t.ch <- c.observedWallTime(originalTriggerTime):
+	default:
+	}
+}
+
+// triggerCatchUpTicker triggers specified ticker exactly once.
+// The next ticker's trigger time is moved forward by a single period.
+// If the previous tick is not received yet, it waits for the ticker's consumer
+// until there is room for the new tick or the ticker is stopped.
+// Lock required, it's released while waiting for the consumer.
+func (c *internalClock) triggerCatchUpTicker(t *internalTimer) {
+	tickTime := c.observedWallTime(t.triggerTime)
+	c.publish(t, ActionFired)
+	t.triggerTime = t.triggerTime.Add(t.duration)
+	heap.Fix(&c.timers, t.index)
+
+	select {
+	case t.ch <- tickTime:
+		return
+	default:
+	}
+
+	stopped := t.stopped
+	c.mu.Unlock()
+
+	select {
+	case t.ch <- tickTime:
+	case <-stopped:
+	}
+
+	c.mu.Lock()
+
+	select {
+	case <-stopped:
+		// The ticker was stopped or reset while the tick was being sent.
+		if c.syncTimerChans {
+			c.drain(t)
+		}
 	default:
 	}
 }
 
 // triggerTimer triggers specified timer.
-// Lock required.
+// The timer's callback is called in its own goroutine
+// unless synchronous callbacks are enabled.
+// Lock required, it's released while calling a synchronous callback.
 func (c *internalClock) triggerTimer(t *internalTimer) {
-	delete(c.timers, t)
+	c.unregister(t)
+	c.publish(t, ActionFired)
+
+	if t.callback != nil && c.syncCallbacks {
+		c.runningCallbacks++
+		triggerTime := c.observedWallTime(t.triggerTime)
+		c.mu.Unlock()
+		defer c.mu.Lock()
+
+		c.runCallback(t, triggerTime)
+		return
+	}
 
 	if t.callback != nil {
-		go t.callback()
+		c.runningCallbacks++
+		go c.runCallback(t, c.observedWallTime(t.triggerTime))
 		return
 	}
 
 	select {
-	case t.ch <- t.triggerTime:
+	case t.ch <- c.observedWallTime(t.triggerTime):
+	default:
+	}
+}
+
+// runCallback calls the timer's callback and marks it done.
+// The trigger time is sent to the timer's channel once the callback returns.
+// The callback must be counted as running before.
+func (c *internalClock) runCallback(t *internalTimer, triggerTime time.Time) {
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.runningCallbacks--
+		c.notifyChanged()
+	}()
+
+	t.callback()
+
+	select {
+	case t.ch <- triggerTime:
 	default:
 	}
 }
 
 // newInternalTimer creates and registres a new internalTimer instance.
-func (c *internalClock) newInternalTimer(d time.Duration, isTicker bool, callback func()) *internalTimer {
+// Tickers deliver every elapsed period if catchUp is set.
+// The creation stack is captured for the diagnostics if creationStacks is set.
+func (c *internalClock) newInternalTimer(kind WaiterKind, label string, d time.Duration, catchUp bool, callback func()) *internalTimer {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if isTicker && callback != nil {
-		panic("unexpected callback for the ticker")
+	if (kind == KindAfterFunc) != (callback != nil) {
+		panic("unexpected callback for the " + kind.String())
+	}
+
+	var stack []uintptr
+	if c.creationStacks {
+		stack = captureStack()
 	}
 
 	t := &internalTimer{
 		clock:       c,
 		ch:          make(chan time.Time, 1),
-		triggerTime: c.now.Add(d),
+		triggerTime: c.triggerTimeAfter(d),
 		callback:    callback,
-		isTicker:    isTicker,
+		kind:        kind,
+		label:       label,
+		catchUp:     catchUp,
 		duration:    d,
+		seq:         c.seq,
+		index:       -1,
+		stopped:     make(chan struct{}),
+		stack:       stack,
 	}
-	c.timers[t] = struct{}{}
+	c.seq++
+	c.register(t)
+	c.publish(t, ActionRegistered)
 
 	return t
 }
 
+// triggerTimeAfter returns the monotonic time after the specified duration.
+// Non-positive durations trigger at the current time, so time never moves backward.
+// Lock required.
+func (c *internalClock) triggerTimeAfter(d time.Duration) time.Time {
+	if d <= 0 {
+		return c.mono
+	}
+	return c.mono.Add(d)
+}
+
+// register adds the timer to the registry.
+// Lock required.
+func (c *internalClock) register(t *internalTimer) {
+	heap.Push(&c.timers, t)
+	c.kindCounts[t.kind]++
+	c.labelCounts[t.label]++
+	c.notifyChanged()
+}
+
+// unregister removes the timer from the registry.
+// Lock required.
+func (c *internalClock) unregister(t *internalTimer) {
+	heap.Remove(&c.timers, t.index)
+	c.kindCounts[t.kind]--
+	c.labelCounts[t.label]--
+	c.notifyChanged()
+}
+
 // stopTimer unregisters specified timer.
 // It returns true if the specified timer was active.
 func (c *internalClock) stopTimer(t *internalTimer) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	_, timerWasActive := c.timers[t]
+	timerWasActive := t.isActive()
 	if timerWasActive {
-		delete(c.timers, t)
+		c.unregister(t)
+		close(t.stopped)
+		c.publish(t, ActionStopped)
+	}
+
+	if c.discardStale(t) {
+		timerWasActive = true
 	}
 
 	return timerWasActive
 }
 
-// resetTimer changes duration for the specified timer.
+// resetTimer changes duration for the specified timer or ticker.
 // Specified timer would be registered again.
+// The next ticker's tick would be triggered after the new duration.
 // resetTimer returns true if specified timer was active.
 func (c *internalClock) resetTimer(t *internalTimer, d time.Duration) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if t.isTicker {
-		panic("ticker cannot be reset")
-	}
-
-	t.triggerTime = c.now.Add(d)
+	t.triggerTime = c.triggerTimeAfter(d)
 	t.duration = d
 
-	_, timerWasActive := c.timers[t]
-	if !timerWasActive {
-		c.timers[t] = struct{}{}
+	timerWasActive := t.isActive()
+	if timerWasActive {
+		heap.Fix(&c.timers, t.index)
+		close(t.stopped)
+	} else {
+		c.register(t)
+	}
+	t.stopped = make(chan struct{})
+	c.publish(t, ActionReset)
+
+	if c.discardStale(t) {
+		timerWasActive = true
 	}
 
 	return timerWasActive
 }
 
+// discardStale removes a not received value from the timer's channel
+// if the Go 1.23 timers semantics is used, see WithGo123Timers.
+// It returns true if there was such value,
+// which means the timer is still running in Go 1.23.
+// AfterFunc timer's value signals the finished callback, so it's kept.
+// Lock required.
+func (c *internalClock) discardStale(t *internalTimer) bool {
+	return c.syncTimerChans && t.kind != KindAfterFunc && c.drain(t)
+}
+
+// drain removes a not received value from the timer's channel.
+// It returns true if there was such value.
+// Lock required.
+func (c *internalClock) drain(t *internalTimer) bool {
+	select {
+	case <-t.ch:
+		return true
+	default:
+		return false
+	}
+}
+
 // waitersCount returns current count of registered timers, tickers and sleepers.
 func (c *internalClock) waitersCount() int {
 	c.mu.Lock()
@@ -160,3 +653,190 @@ func (c *internalClock) waitersCount() int {
 
 	return len(c.timers)
 }
+
+// waitForWaiters blocks until the count of registered timers, tickers and sleepers
+// becomes equal to n or the specified context is done.
+// It returns the context's error in the latter case.
+func (c *internalClock) waitForWaiters(ctx context.Context, n int) error {
+	return c.waitFor(ctx, func() bool {
+		return len(c.timers) == n
+	})
+}
+
+// kindCount returns current count of registered waiters of the specified kind.
+func (c *internalClock) kindCount(kind WaiterKind) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.kindCounts[kind]
+}
+
+// waitForKind blocks until the count of registered waiters of the specified kind
+// becomes equal to n or the specified context is done.
+// It returns the context's error in the latter case.
+func (c *internalClock) waitForKind(ctx context.Context, kind WaiterKind, n int) error {
+	return c.waitFor(ctx, func() bool {
+		return c.kindCounts[kind] == n
+	})
+}
+
+// waitForLabel blocks until there is a registered waiter with the specified label
+// or the specified context is done.
+// It returns the context's error in the latter case.
+func (c *internalClock) waitForLabel(ctx context.Context, label string) error {
+	return c.waitFor(ctx, func() bool {
+		return c.labelCounts[label] > 0
+	})
+}
+
+// callbacksCount returns current count of triggered but not finished callbacks.
+func (c *internalClock) callbacksCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.runningCallbacks
+}
+
+// waitForCallbacks blocks until all triggered callbacks are finished
+// or the specified context is done.
+// It returns the context's error in the latter case.
+func (c *internalClock) waitForCallbacks(ctx context.Context) error {
+	return c.waitFor(ctx, func() bool {
+		return c.runningCallbacks == 0
+	})
+}
+
+// waitFor blocks until the condition is met or the specified context is done.
+// The condition is checked with the lock held every time the clock's state changes.
+// It returns the context's error if the context is done first.
+func (c *internalClock) waitFor(ctx context.Context, cond func() bool) error {
+	for {
+		c.mu.Lock()
+		ok := cond()
+		changed := c.changed
+		c.mu.Unlock()
+
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// notifyChanged wakes up everyone waiting for the clock's state changes.
+// Lock required.
+func (c *internalClock) notifyChanged() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// subscribe registers a new waiter events subscriber.
+func (c *internalClock) subscribe() *subscriber {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := newSubscriber()
+	c.subscribers[s] = struct{}{}
+
+	return s
+}
+
+// unsubscribe unregisters and closes specified subscriber.
+func (c *internalClock) unsubscribe(s *subscriber) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.subscribers[s]; ok {
+		delete(c.subscribers, s)
+		close(s.done)
+	}
+}
+
+// publish sends the waiter event about specified timer to all subscribers.
+// Lock required.
+func (c *internalClock) publish(t *internalTimer, action WaiterAction) {
+	if len(c.subscribers) == 0 {
+		return
+	}
+
+	e := WaiterEvent{
+		Kind:        t.kind,
+		Label:       t.label,
+		Action:      action,
+		Duration:    t.duration,
+		TriggerTime: c.wallTime(t.triggerTime),
+	}
+	for s := range c.subscribers {
+		s.push(e)
+	}
+}
+
+// waiters returns descriptions of registered timers, tickers and sleepers in the trigger order.
+func (c *internalClock) waiters() []WaiterInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timers := append([]*internalTimer(nil), c.timers...)
+	sort.Slice(timers, func(i, j int) bool {
+		return timers[i].before(timers[j])
+	})
+
+	waiters := make([]WaiterInfo, 0, len(timers))
+	for _, t := range timers {
+		waiters = append(waiters, t.info())
+	}
+
+	return waiters
+}
+
+// describeWaiters returns a human readable list
+// of registered timers, tickers and sleepers in the trigger order.
+func (c *internalClock) describeWaiters() string {
+	waiters := c.waiters()
+
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "%d waiters registered, current time %s", len(waiters), c.getCurrentTime())
+	for _, w := range waiters {
+		fmt.Fprintf(&b, "\n\t%s", w)
+	}
+
+	return b.String()
+}
+
+// maxStackDepth is the maximum count of captured timer's creation stack frames.
+const maxStackDepth = 32
+
+// captureStack returns the caller's stack excluding the captureStack's own frame.
+func captureStack() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	return pcs[:n]
+}
+
+// pkgPrefix is the prefix of this package's function names.
+var pkgPrefix = reflect.TypeOf(internalClock{}).PkgPath() + "."
+
+// callSite returns the location of the first stack frame
+// outside this package and the runtime.
+// It returns an empty string if the stack isn't captured.
+func callSite(stack []uintptr) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) && !strings.HasPrefix(frame.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
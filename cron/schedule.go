@@ -0,0 +1,307 @@
+// Package cron parses cron specs and runs the scheduled jobs on the clock.Clock.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule describes the job's activation times.
+type Schedule interface {
+	// Next returns the next activation time strictly after t,
+	// or the zero time if there are no more activations.
+	Next(t time.Time) time.Time
+}
+
+// Parse parses the cron spec in the local time zone.
+// See ParseInLocation for the supported formats.
+func Parse(spec string) (Schedule, error) {
+	return ParseInLocation(spec, time.Local)
+}
+
+// ParseInLocation parses the cron spec with activation times in the specified location.
+// The supported formats are:
+//   - 5 fields: minute, hour, day of month, month, day of week;
+//   - 6 fields: second, minute, hour, day of month, month, day of week;
+//   - descriptors: @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly;
+//   - @every <duration>, where the duration is in time.ParseDuration's format.
+//
+// Fields support *, ?, lists, ranges, steps, and month and day of week names.
+// The day matches if either the day of month or the day of week matches
+// when both of them are restricted, as in the standard cron.
+func ParseInLocation(spec string, loc *time.Location) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		return parseDescriptor(spec, loc)
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron: expected 5 or 6 fields, found %d in %q", len(fields), spec)
+	}
+
+	s := &specSchedule{loc: loc}
+	masks := []*uint64{&s.second, &s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, b := range fieldBounds {
+		mask, err := parseField(fields[i], b)
+		if err != nil {
+			return nil, fmt.Errorf("cron: invalid %s field in %q: %s", b.name, spec, err)
+		}
+		*masks[i] = mask
+	}
+
+	// Sunday is both 0 and 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = isAny(fields[3])
+	s.dowAny = isAny(fields[5])
+
+	return s, nil
+}
+
+// MustParse is like Parse but panics if the spec can't be parsed.
+func MustParse(spec string) Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// every is the schedule activated with the constant interval.
+type every struct {
+	interval time.Duration
+}
+
+// Next implements Schedule.
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(e.interval)
+}
+
+// descriptors maps the descriptors to the equivalent 6 fields specs.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// parseDescriptor parses the spec that starts with @.
+func parseDescriptor(spec string, loc *time.Location) (Schedule, error) {
+	if fields, ok := descriptors[spec]; ok {
+		return ParseInLocation(fields, loc)
+	}
+
+	const prefix = "@every "
+	if !strings.HasPrefix(spec, prefix) {
+		return nil, fmt.Errorf("cron: unknown descriptor %q", spec)
+	}
+
+	interval, err := time.ParseDuration(strings.TrimSpace(spec[len(prefix):]))
+	if err != nil {
+		return nil, fmt.Errorf("cron: invalid interval in %q: %s", spec, err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("cron: non-positive interval in %q", spec)
+	}
+	return every{interval: interval}, nil
+}
+
+// bounds describes the field's values.
+type bounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+// fieldBounds lists the bounds of the 6 fields in order.
+var fieldBounds = []bounds{
+	{name: "second", min: 0, max: 59},
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// isAny reports whether the field starts with the wildcard.
+func isAny(field string) bool {
+	return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+}
+
+// parseField parses the field to the bit mask of its values.
+func parseField(field string, b bounds) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		r, stepStr, hasStep := strings.Cut(part, "/")
+
+		var lo, hi int
+		switch {
+		case r == "*" || r == "?":
+			lo, hi = b.min, b.max
+		case strings.Contains(r, "-"):
+			loStr, hiStr, _ := strings.Cut(r, "-")
+			var err error
+			if lo, err = parseValue(loStr, b); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(hiStr, b); err != nil {
+				return 0, err
+			}
+		default:
+			v, err := parseValue(r, b)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			if hasStep {
+				hi = b.max
+			}
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("range %d-%d is reversed", lo, hi)
+		}
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// parseValue parses the single field's value or name.
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d is out of range [%d, %d]", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+// allHours is the hour field's mask that matches every hour.
+const allHours = 1<<24 - 1
+
+// specSchedule is the schedule parsed from the cron fields.
+type specSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	domAny, dowAny                        bool
+	loc                                   *time.Location
+}
+
+// Next implements Schedule.
+// The schedule that matches every hour follows the absolute time,
+// so it's activated in both the skipped and the repeated hours of DST transitions.
+// Otherwise it follows the wall clock time: an activation in the repeated hour
+// happens once, and an activation in the skipped hour happens at the transition.
+func (s *specSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc)
+	if s.hour == allHours {
+		return s.next(t, s.loc)
+	}
+
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	for {
+		wall = s.next(wall, time.UTC)
+		if wall.IsZero() {
+			return time.Time{}
+		}
+
+		next := inLocation(wall, s.loc)
+		if !sameWallTime(next, wall) {
+			next = afterGap(wall, s.loc)
+		}
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+// next returns the first matching time after t walking in the specified location.
+// It returns the zero time if there is no match in 5 years.
+func (s *specSchedule) next(t time.Time, loc *time.Location) time.Time {
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + 5
+
+	for t.Year() <= yearLimit {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		case s.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of month and the day of week match.
+func (s *specSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// inLocation returns the time with the wall clock of the UTC time in the location.
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+}
+
+// sameWallTime reports whether the time has the wall clock of the UTC time.
+func sameWallTime(t, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 &&
+		t.Hour() == wall.Hour() && t.Minute() == wall.Minute() && t.Second() == wall.Second()
+}
+
+// afterGap returns the end of the DST gap that skips the wall clock time.
+func afterGap(wall time.Time, loc *time.Location) time.Time {
+	wall = wall.Truncate(time.Minute)
+	for i := 0; i < 24*60; i++ {
+		wall = wall.Add(time.Minute)
+		if t := inLocation(wall, loc); sameWallTime(t, wall) {
+			return t
+		}
+	}
+	return inLocation(wall, loc)
+}
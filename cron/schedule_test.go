@@ -0,0 +1,219 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock/cron"
+
+	_ "time/tzdata"
+)
+
+// loadLocation loads the location or fails the test.
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Unexpected LoadLocation error: %s", err)
+	}
+	return loc
+}
+
+// expectNext checks the schedule's activation times after the start.
+func expectNext(t *testing.T, s cron.Schedule, start time.Time, expected ...time.Time) {
+	t.Helper()
+	next := start
+	for i, exp := range expected {
+		next = s.Next(next)
+		if !next.Equal(exp) {
+			t.Fatalf("Unexpected activation %d, expected=%s, actual=%s", i, exp, next)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"x * * * *",
+		"* * * foo *",
+		"@never",
+		"@every",
+		"@every -1s",
+	}
+	for _, spec := range specs {
+		if _, err := cron.ParseInLocation(spec, time.UTC); err == nil {
+			t.Fatalf("Expected error for spec %q", spec)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	start := time.Date(2024, 1, 31, 23, 59, 30, 0, time.UTC)
+
+	cases := []struct {
+		spec     string
+		expected []time.Time
+	}{
+		{"* * * * *", []time.Time{
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 0, 1, 0, 0, time.UTC),
+		}},
+		{"*/20 * * * * *", []time.Time{
+			time.Date(2024, 1, 31, 23, 59, 40, 0, time.UTC),
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 0, 0, 20, 0, time.UTC),
+		}},
+		{"15,45 9-10 * * *", []time.Time{
+			time.Date(2024, 2, 1, 9, 15, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 9, 45, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 10, 15, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 10, 45, 0, 0, time.UTC),
+			time.Date(2024, 2, 2, 9, 15, 0, 0, time.UTC),
+		}},
+		{"0 12 * * MON-FRI", []time.Time{
+			time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 2, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 5, 12, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 29 feb *", []time.Time{
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 13 * 5", []time.Time{
+			time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 16, 0, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 * * 7", []time.Time{
+			time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@monthly", []time.Time{
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@weekly", []time.Time{
+			time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@hourly", []time.Time{
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC),
+		}},
+		{"@every 90m", []time.Time{
+			time.Date(2024, 2, 1, 1, 29, 30, 0, time.UTC),
+			time.Date(2024, 2, 1, 2, 59, 30, 0, time.UTC),
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := cron.ParseInLocation(tc.spec, time.UTC)
+			if err != nil {
+				t.Fatalf("Unexpected Parse error: %s", err)
+			}
+			expectNext(t, s, start, tc.expected...)
+		})
+	}
+}
+
+func TestScheduleNever(t *testing.T) {
+	s, err := cron.ParseInLocation("0 0 30 2 *", time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected Parse error: %s", err)
+	}
+	if next := s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Fatalf("Unexpected activation, expected=zero time, actual=%s", next)
+	}
+}
+
+func TestScheduleLocation(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	s, err := cron.ParseInLocation("0 9 * * *", tokyo)
+	if err != nil {
+		t.Fatalf("Unexpected Parse error: %s", err)
+	}
+
+	expectNext(t, s, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+	)
+}
+
+func TestScheduleDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	cases := []struct {
+		name     string
+		spec     string
+		start    time.Time
+		expected []time.Time
+	}{
+		{
+			name:  "skipped hour runs at transition",
+			spec:  "30 2 * * *",
+			start: time.Date(2024, 3, 9, 0, 0, 0, 0, ny),
+			expected: []time.Time{
+				time.Date(2024, 3, 9, 7, 30, 0, 0, time.UTC),
+				time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 11, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "repeated hour runs once",
+			spec:  "30 1 * * *",
+			start: time.Date(2024, 11, 2, 0, 0, 0, 0, ny),
+			expected: []time.Time{
+				time.Date(2024, 11, 2, 5, 30, 0, 0, time.UTC),
+				time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC),
+				time.Date(2024, 11, 4, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "hourly in skipped hour",
+			spec:  "0 * * * *",
+			start: time.Date(2024, 3, 10, 0, 0, 0, 0, ny),
+			expected: []time.Time{
+				time.Date(2024, 3, 10, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "hourly in repeated hour",
+			spec:  "0 * * * *",
+			start: time.Date(2024, 11, 3, 0, 0, 0, 0, ny),
+			expected: []time.Time{
+				time.Date(2024, 11, 3, 5, 0, 0, 0, time.UTC),
+				time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "start in repeated hour",
+			spec:  "45 1 * * *",
+			start: time.Date(2024, 11, 3, 6, 50, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 11, 4, 6, 45, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := cron.ParseInLocation(tc.spec, ny)
+			if err != nil {
+				t.Fatalf("Unexpected Parse error: %s", err)
+			}
+			expectNext(t, s, tc.start, tc.expected...)
+		})
+	}
+}
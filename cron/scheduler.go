@@ -0,0 +1,226 @@
+package cron
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// EntryID identifies the scheduled job.
+type EntryID int
+
+// Entry describes the scheduled job.
+type Entry struct {
+	ID       EntryID
+	Schedule Schedule
+	Job      func()
+	// Next is the next activation time, it's zero if there are no more activations
+	// or the scheduler isn't started.
+	Next time.Time
+	// Prev is the last activation time, it's zero if the job wasn't activated yet.
+	Prev time.Time
+}
+
+// Option configures the Scheduler.
+type Option func(*Scheduler)
+
+// WithLocation sets the location of the specs' activation times.
+// The local time zone is used by default.
+func WithLocation(loc *time.Location) Option {
+	return func(s *Scheduler) {
+		s.loc = loc
+	}
+}
+
+// Scheduler runs jobs according to their schedules.
+// Every entry has its own AfterFunc timer armed to the next activation,
+// the job is run in the timer's callback.
+// Activations missed while the job was running are run one after another
+// once it returns, so the same job is never run concurrently.
+// The next activation is armed when the job returns, so with the fake clock's
+// WithSyncCallbacks a single FakeClock.Advance by 3 days runs a nightly job 3 times,
+// each at its activation time.
+type Scheduler struct {
+	clock clock.Clock
+	loc   *time.Location
+
+	mu      sync.Mutex
+	entries []*entry
+	nextID  EntryID
+	running bool
+	jobs    sync.WaitGroup
+}
+
+// entry is the scheduled job with its timer.
+type entry struct {
+	Entry
+
+	timer   clock.Timer
+	busy    bool
+	removed bool
+}
+
+// NewScheduler returns a new stopped scheduler.
+func NewScheduler(c clock.Clock, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		clock: c,
+		loc:   time.Local,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Add parses the spec in the scheduler's location and schedules the job.
+func (s *Scheduler) Add(spec string, job func()) (EntryID, error) {
+	schedule, err := ParseInLocation(spec, s.loc)
+	if err != nil {
+		return 0, err
+	}
+	return s.Schedule(schedule, job), nil
+}
+
+// Schedule schedules the job.
+func (s *Scheduler) Schedule(schedule Schedule, job func()) EntryID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	e := &entry{
+		Entry: Entry{
+			ID:       s.nextID,
+			Schedule: schedule,
+			Job:      job,
+		},
+	}
+	s.entries = append(s.entries, e)
+
+	if s.running {
+		e.Next = schedule.Next(s.clock.Now())
+		s.arm(e)
+	}
+	return e.ID
+}
+
+// Remove unschedules the job.
+func (s *Scheduler) Remove(id EntryID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, e := range s.entries {
+		if e.ID == id {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			e.removed = true
+			if e.timer != nil {
+				e.timer.Stop()
+			}
+			return
+		}
+	}
+}
+
+// Entries returns the scheduled jobs ordered by the next activation time.
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e.Entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return before(entries[i].Next, entries[j].Next)
+	})
+	return entries
+}
+
+// Start arms the timers of the scheduled jobs.
+// It does nothing if the scheduler is already started.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.running = true
+
+	now := s.clock.Now()
+	for _, e := range s.entries {
+		e.Next = e.Schedule.Next(now)
+		s.arm(e)
+	}
+}
+
+// Stop stops the timers and waits for the running jobs to finish.
+// It does nothing if the scheduler isn't started.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	for _, e := range s.entries {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+	}
+	s.mu.Unlock()
+
+	s.jobs.Wait()
+}
+
+// fire runs the entry's due activations one after another
+// and arms the entry's timer to the next one.
+// Stale timer callbacks find no due activations and just arm the timer again.
+func (s *Scheduler) fire(e *entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running || e.removed || e.busy {
+		// The running job arms the timer when it returns.
+		return
+	}
+
+	e.busy = true
+	s.jobs.Add(1)
+	for s.running && !e.removed && !e.Next.IsZero() && !e.Next.After(s.clock.Now()) {
+		e.Prev = e.Next
+		e.Next = e.Schedule.Next(e.Next)
+
+		s.mu.Unlock()
+		e.Job()
+		s.mu.Lock()
+	}
+	e.busy = false
+	s.jobs.Done()
+
+	s.arm(e)
+}
+
+// arm schedules the entry's timer to the next activation.
+// Lock required.
+func (s *Scheduler) arm(e *entry) {
+	if !s.running || e.removed || e.Next.IsZero() {
+		return
+	}
+
+	d := e.Next.Sub(s.clock.Now())
+	if e.timer == nil {
+		e.timer = s.clock.AfterFunc(d, func() { s.fire(e) })
+		return
+	}
+	e.timer.Reset(d)
+}
+
+// before reports whether a is before b, the zero time is after any other time.
+func before(a, b time.Time) bool {
+	if a.IsZero() {
+		return false
+	}
+	return b.IsZero() || a.Before(b)
+}
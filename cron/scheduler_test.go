@@ -0,0 +1,247 @@
+package cron_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/cron"
+)
+
+// recordJob returns the job that sends its activation times to the channel.
+func recordJob(c clock.Clock) (func(), <-chan time.Time) {
+	ch := make(chan time.Time, 16)
+	return func() { ch <- c.Now() }, ch
+}
+
+// expectRun waits for the job's activation and checks its time.
+func expectRun(t *testing.T, ch <-chan time.Time, expected time.Time) {
+	t.Helper()
+	select {
+	case actual := <-ch:
+		if !actual.Equal(expected) {
+			t.Fatalf("Unexpected activation, expected=%s, actual=%s", expected, actual)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Job wasn't run at %s", expected)
+	}
+}
+
+// advanceToNext moves the clock to the trigger time of the next scheduler's timer.
+func advanceToNext(t *testing.T, c clock.FakeClock) {
+	t.Helper()
+	if _, ok := c.AdvanceToNext(); !ok {
+		t.Fatal("Expected the scheduler's timer to fire")
+	}
+}
+
+func TestSchedulerNightlyJob(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	c := clock.NewFakeClockAt(time.Date(2024, 3, 8, 12, 0, 0, 0, ny), clock.WithSyncCallbacks())
+	s := cron.NewScheduler(c, cron.WithLocation(ny))
+
+	job, runs := recordJob(c)
+	if _, err := s.Add("30 2 * * *", job); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	expected := []time.Time{
+		time.Date(2024, 3, 9, 2, 30, 0, 0, ny),
+		time.Date(2024, 3, 10, 3, 0, 0, 0, ny),
+		time.Date(2024, 3, 11, 2, 30, 0, 0, ny),
+		time.Date(2024, 3, 12, 2, 30, 0, 0, ny),
+	}
+	for _, exp := range expected {
+		advanceToNext(t, c)
+		expectRun(t, runs, exp)
+	}
+
+	entries := s.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Unexpected entries count, expected=1, actual=%d", len(entries))
+	}
+	if !entries[0].Prev.Equal(expected[3]) {
+		t.Fatalf("Unexpected previous activation, expected=%s, actual=%s", expected[3], entries[0].Prev)
+	}
+	if exp := time.Date(2024, 3, 13, 2, 30, 0, 0, ny); !entries[0].Next.Equal(exp) {
+		t.Fatalf("Unexpected next activation, expected=%s, actual=%s", exp, entries[0].Next)
+	}
+}
+
+func TestSchedulerMultipleJobs(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClockAt(start, clock.WithSyncCallbacks())
+	s := cron.NewScheduler(c, cron.WithLocation(time.UTC))
+
+	hourly, hourlyRuns := recordJob(c)
+	every, everyRuns := recordJob(c)
+	if _, err := s.Add("@hourly", hourly); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	everyID, err := s.Add("@every 40m", every)
+	if err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	advanceToNext(t, c)
+	expectRun(t, everyRuns, start.Add(40*time.Minute))
+	advanceToNext(t, c)
+	expectRun(t, hourlyRuns, start.Add(time.Hour))
+	advanceToNext(t, c)
+	expectRun(t, everyRuns, start.Add(80*time.Minute))
+
+	s.Remove(everyID)
+	advanceToNext(t, c)
+	expectRun(t, hourlyRuns, start.Add(2*time.Hour))
+
+	select {
+	case tm := <-everyRuns:
+		t.Fatalf("Unexpected run of the removed job at %s", tm)
+	default:
+	}
+}
+
+func TestSchedulerAddWhileRunning(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClockAt(start, clock.WithSyncCallbacks())
+	s := cron.NewScheduler(c, cron.WithLocation(time.UTC))
+
+	daily, dailyRuns := recordJob(c)
+	if _, err := s.Add("@daily", daily); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	minutely, minutelyRuns := recordJob(c)
+	if _, err := s.Add("* * * * *", minutely); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+
+	if trigger := nextTrigger(c); !trigger.Equal(start.Add(time.Minute)) {
+		t.Fatalf("Unexpected trigger time of the added job, expected=%s, actual=%s", start.Add(time.Minute), trigger)
+	}
+	advanceToNext(t, c)
+	expectRun(t, minutelyRuns, start.Add(time.Minute))
+
+	select {
+	case tm := <-dailyRuns:
+		t.Fatalf("Unexpected run of the daily job at %s", tm)
+	default:
+	}
+}
+
+// nextTrigger returns the trigger time of the first clock's waiter.
+func nextTrigger(c clock.FakeClock) time.Time {
+	waiters := c.Waiters()
+	if len(waiters) == 0 {
+		return time.Time{}
+	}
+	return waiters[0].TriggerTime
+}
+
+func TestSchedulerRunsEveryActivationOnSingleAdvance(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	c := clock.NewFakeClockAt(time.Date(2024, 3, 8, 12, 0, 0, 0, ny), clock.WithSyncCallbacks())
+	s := cron.NewScheduler(c, cron.WithLocation(ny))
+
+	job, runs := recordJob(c)
+	if _, err := s.Add("30 2 * * *", job); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	s.Start()
+	defer s.Stop()
+
+	c.Advance(4 * 24 * time.Hour)
+	for _, exp := range []time.Time{
+		time.Date(2024, 3, 9, 2, 30, 0, 0, ny),
+		time.Date(2024, 3, 10, 3, 0, 0, 0, ny),
+		time.Date(2024, 3, 11, 2, 30, 0, 0, ny),
+		time.Date(2024, 3, 12, 2, 30, 0, 0, ny),
+	} {
+		expectRun(t, runs, exp)
+	}
+
+	entries := s.Entries()
+	if exp := time.Date(2024, 3, 12, 2, 30, 0, 0, ny); !entries[0].Prev.Equal(exp) {
+		t.Fatalf("Unexpected previous activation, expected=%s, actual=%s", exp, entries[0].Prev)
+	}
+	if exp := time.Date(2024, 3, 13, 2, 30, 0, 0, ny); !entries[0].Next.Equal(exp) {
+		t.Fatalf("Unexpected next activation, expected=%s, actual=%s", exp, entries[0].Next)
+	}
+	select {
+	case tm := <-runs:
+		t.Fatalf("Unexpected extra run at %s", tm)
+	default:
+	}
+}
+
+func TestSchedulerCatchesUpMissedActivationsSequentially(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFakeClockAt(start)
+	s := cron.NewScheduler(c, cron.WithLocation(time.UTC))
+
+	var active int32
+	record, runs := recordJob(c)
+	job := func() {
+		if n := atomic.AddInt32(&active, 1); n != 1 {
+			t.Errorf("Unexpected concurrent runs count, expected=1, actual=%d", n)
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		record()
+	}
+	if _, err := s.Add("@daily", job); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	s.Start()
+
+	// The callback is run asynchronously, so the missed activations are run at the end.
+	c.Advance(4 * 24 * time.Hour)
+	end := c.Now()
+	for i := 0; i < 4; i++ {
+		expectRun(t, runs, end)
+	}
+	s.Stop()
+
+	entries := s.Entries()
+	if exp := start.Add(4 * 24 * time.Hour); !entries[0].Prev.Equal(exp) {
+		t.Fatalf("Unexpected previous activation, expected=%s, actual=%s", exp, entries[0].Prev)
+	}
+	select {
+	case tm := <-runs:
+		t.Fatalf("Unexpected extra run at %s", tm)
+	default:
+	}
+}
+
+func TestSchedulerStop(t *testing.T) {
+	c := clock.NewFakeClock()
+	s := cron.NewScheduler(c)
+
+	job, runs := recordJob(c)
+	if _, err := s.Add("@every 1s", job); err != nil {
+		t.Fatalf("Unexpected Add error: %s", err)
+	}
+	s.Start()
+	if n := c.WaitersCount(); n != 1 {
+		t.Fatalf("Unexpected waiters count after start, expected=1, actual=%d", n)
+	}
+	s.Stop()
+
+	if n := c.WaitersCount(); n != 0 {
+		t.Fatalf("Unexpected waiters count after stop, expected=0, actual=%d", n)
+	}
+	c.Advance(time.Hour)
+	select {
+	case tm := <-runs:
+		t.Fatalf("Unexpected run after stop at %s", tm)
+	default:
+	}
+	s.Stop()
+}
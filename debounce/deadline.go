@@ -0,0 +1,61 @@
+package debounce
+
+import (
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// deadlineTimer calls the function at the deadline
+// measured as the time elapsed since the timer's creation.
+// Stop and Reset don't cancel a callback that has already started,
+// so a stale callback may run after the deadline was moved.
+// Stale callbacks are detected by comparing the current time with the deadline.
+type deadlineTimer struct {
+	clock    clock.Clock
+	created  time.Time
+	fn       func()
+	timer    clock.Timer
+	deadline time.Duration
+}
+
+// newDeadlineTimer returns a new not armed timer that calls fn at the deadline.
+func newDeadlineTimer(c clock.Clock, fn func()) *deadlineTimer {
+	return &deadlineTimer{
+		clock:   c,
+		created: c.Now(),
+		fn:      fn,
+	}
+}
+
+// arm schedules the function call to the deadline.
+func (t *deadlineTimer) arm(now, deadline time.Duration) {
+	t.deadline = deadline
+	if t.timer == nil {
+		t.timer = t.clock.AfterFunc(deadline-now, t.fn)
+		return
+	}
+	t.timer.Reset(deadline - now)
+}
+
+// due reports whether the deadline is reached.
+// Otherwise the callback is stale and the timer is armed again.
+func (t *deadlineTimer) due(now time.Duration) bool {
+	if now < t.deadline {
+		t.arm(now, t.deadline)
+		return false
+	}
+	return true
+}
+
+// stop cancels the scheduled function call.
+func (t *deadlineTimer) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// now returns the time elapsed since the timer's creation.
+func (t *deadlineTimer) now() time.Duration {
+	return t.clock.Since(t.created)
+}
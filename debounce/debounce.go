@@ -0,0 +1,175 @@
+// Package debounce provides the debouncer and the throttler built on the clock's AfterFunc timers.
+package debounce
+
+import (
+	"sync"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// Option configures the Debouncer.
+type Option func(*Debouncer)
+
+// WithLeading sets whether the function is called on the leading edge of a burst.
+// It's disabled by default.
+func WithLeading(leading bool) Option {
+	return func(d *Debouncer) {
+		d.leading = leading
+	}
+}
+
+// WithTrailing sets whether the function is called on the trailing edge of a burst.
+// It's enabled by default.
+func WithTrailing(trailing bool) Option {
+	return func(d *Debouncer) {
+		d.trailing = trailing
+	}
+}
+
+// WithMaxWait limits the delay of the trailing call during a continuous burst.
+// Zero means no limit.
+func WithMaxWait(maxWait time.Duration) Option {
+	return func(d *Debouncer) {
+		d.maxWait = maxWait
+	}
+}
+
+// Debouncer coalesces bursts of calls into a single function call.
+// A burst ends when there were no calls for the wait duration.
+// The trailing call is made in the timer's goroutine.
+type Debouncer struct {
+	fn       func()
+	wait     time.Duration
+	maxWait  time.Duration
+	leading  bool
+	trailing bool
+
+	mu       sync.Mutex
+	timer    *deadlineTimer
+	active   bool
+	pending  bool
+	lastCall time.Duration
+	anchor   time.Duration
+}
+
+// New returns a new debouncer that calls fn after the wait duration since the last call.
+func New(c clock.Clock, wait time.Duration, fn func(), opts ...Option) *Debouncer {
+	d := &Debouncer{
+		fn:       fn,
+		wait:     wait,
+		trailing: true,
+	}
+	d.timer = newDeadlineTimer(c, d.fire)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Call schedules the function call.
+// The function is called immediately if it's the first call of a burst
+// and the leading edge is enabled.
+func (d *Debouncer) Call() {
+	d.mu.Lock()
+
+	now := d.timer.now()
+	d.lastCall = now
+
+	invoke := false
+	if !d.active {
+		d.active = true
+		d.anchor = now
+		if d.leading {
+			invoke = true
+		} else {
+			d.pending = d.trailing
+		}
+	} else if d.trailing {
+		d.pending = true
+	}
+
+	d.timer.arm(now, d.deadline())
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn()
+	}
+}
+
+// Cancel drops the pending trailing call and ends the current burst.
+func (d *Debouncer) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.reset()
+}
+
+// Flush makes the pending trailing call immediately and ends the current burst.
+// It reports whether the function was called.
+func (d *Debouncer) Flush() bool {
+	d.mu.Lock()
+	invoke := d.pending
+	d.reset()
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn()
+	}
+	return invoke
+}
+
+// Pending reports whether there is a pending trailing call.
+func (d *Debouncer) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.pending
+}
+
+// fire handles the timer.
+func (d *Debouncer) fire() {
+	d.mu.Lock()
+
+	if !d.active {
+		d.mu.Unlock()
+		return
+	}
+
+	now := d.timer.now()
+	if !d.timer.due(now) {
+		d.mu.Unlock()
+		return
+	}
+
+	invoke := d.pending
+	d.pending = false
+	if now >= d.lastCall+d.wait {
+		d.active = false
+	} else {
+		// The max wait has elapsed during the burst.
+		d.anchor = now
+		d.timer.arm(now, d.deadline())
+	}
+	d.mu.Unlock()
+
+	if invoke {
+		d.fn()
+	}
+}
+
+// deadline returns the time of the next timer's fire.
+func (d *Debouncer) deadline() time.Duration {
+	deadline := d.lastCall + d.wait
+	if d.pending && d.maxWait > 0 && d.anchor+d.maxWait < deadline {
+		deadline = d.anchor + d.maxWait
+	}
+	return deadline
+}
+
+// reset stops the timer and ends the current burst.
+func (d *Debouncer) reset() {
+	d.timer.stop()
+	d.active = false
+	d.pending = false
+}
@@ -0,0 +1,163 @@
+package debounce_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/debounce"
+)
+
+// counter counts the function calls.
+type counter struct {
+	n int32
+}
+
+func (c *counter) inc() {
+	atomic.AddInt32(&c.n, 1)
+}
+
+func (c *counter) expect(t *testing.T, expected int32) {
+	t.Helper()
+	if n := atomic.LoadInt32(&c.n); n != expected {
+		t.Fatalf("Unexpected calls count, expected=%d, actual=%d", expected, n)
+	}
+}
+
+func TestDebouncerTrailing(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc)
+
+	d.Call()
+	c.Advance(50 * time.Millisecond)
+	d.Call()
+	c.Advance(99 * time.Millisecond)
+	calls.expect(t, 0)
+	if !d.Pending() {
+		t.Fatal("Expected pending call before the wait elapsed")
+	}
+
+	c.Advance(time.Millisecond)
+	calls.expect(t, 1)
+	if d.Pending() {
+		t.Fatal("Unexpected pending call after the trailing call")
+	}
+
+	c.Advance(time.Hour)
+	calls.expect(t, 1)
+}
+
+func TestDebouncerLeading(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc, debounce.WithLeading(true), debounce.WithTrailing(false))
+
+	d.Call()
+	calls.expect(t, 1)
+	c.Advance(50 * time.Millisecond)
+	d.Call()
+	calls.expect(t, 1)
+
+	c.Advance(99 * time.Millisecond)
+	d.Call()
+	calls.expect(t, 1)
+
+	c.Advance(100 * time.Millisecond)
+	calls.expect(t, 1)
+	d.Call()
+	calls.expect(t, 2)
+}
+
+func TestDebouncerLeadingAndTrailing(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc, debounce.WithLeading(true))
+
+	d.Call()
+	calls.expect(t, 1)
+	c.Advance(100 * time.Millisecond)
+	calls.expect(t, 1)
+
+	d.Call()
+	d.Call()
+	calls.expect(t, 2)
+	c.Advance(100 * time.Millisecond)
+	calls.expect(t, 3)
+}
+
+func TestDebouncerMaxWait(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc, debounce.WithMaxWait(250*time.Millisecond))
+
+	for i := 0; i < 10; i++ {
+		d.Call()
+		c.Advance(50 * time.Millisecond)
+		if i == 4 {
+			calls.expect(t, 1)
+		}
+	}
+	calls.expect(t, 2)
+
+	c.Advance(time.Hour)
+	calls.expect(t, 2)
+	if n := c.WaitersCount(); n != 0 {
+		t.Fatalf("Unexpected waiters count, expected=0, actual=%d", n)
+	}
+}
+
+func TestDebouncerFlush(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc)
+
+	if d.Flush() {
+		t.Fatal("Unexpected flush without pending call")
+	}
+
+	d.Call()
+	if !d.Flush() {
+		t.Fatal("Expected flush of the pending call")
+	}
+	calls.expect(t, 1)
+
+	c.Advance(time.Hour)
+	calls.expect(t, 1)
+}
+
+func TestDebouncerCancel(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc)
+
+	d.Call()
+	d.Cancel()
+	if n := c.WaitersCount(); n != 0 {
+		t.Fatalf("Unexpected waiters count after cancel, expected=0, actual=%d", n)
+	}
+
+	c.Advance(time.Hour)
+	calls.expect(t, 0)
+
+	d.Call()
+	c.Advance(100 * time.Millisecond)
+	calls.expect(t, 1)
+}
+
+func TestDebouncerAsyncCallbacks(t *testing.T) {
+	c := clock.NewFakeClock()
+	var calls counter
+	d := debounce.New(c, 100*time.Millisecond, calls.inc)
+
+	for i := 0; i < 3; i++ {
+		d.Call()
+		c.Advance(100 * time.Millisecond)
+		if err := c.WaitCallbacks(context.Background()); err != nil {
+			t.Fatalf("Unexpected WaitCallbacks error: %s", err)
+		}
+		calls.expect(t, int32(i+1))
+	}
+}
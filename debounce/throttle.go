@@ -0,0 +1,101 @@
+package debounce
+
+import (
+	"sync"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// Throttler limits the function calls to at most one per interval.
+// The first call is made immediately, and the calls made during the interval
+// are coalesced into a single call at its end.
+// The trailing call is made in the timer's goroutine.
+type Throttler struct {
+	fn       func()
+	interval time.Duration
+
+	mu      sync.Mutex
+	timer   *deadlineTimer
+	active  bool
+	pending bool
+}
+
+// NewThrottler returns a new throttler that calls fn at most once per interval.
+func NewThrottler(c clock.Clock, interval time.Duration, fn func()) *Throttler {
+	t := &Throttler{
+		fn:       fn,
+		interval: interval,
+	}
+	t.timer = newDeadlineTimer(c, t.fire)
+	return t
+}
+
+// Call calls the function immediately if no interval is in progress,
+// otherwise it schedules the call to the end of the interval.
+func (t *Throttler) Call() {
+	t.mu.Lock()
+
+	if t.active {
+		t.pending = true
+		t.mu.Unlock()
+		return
+	}
+
+	t.active = true
+	t.start(t.timer.now())
+	t.mu.Unlock()
+
+	t.fn()
+}
+
+// Cancel drops the pending call and ends the current interval.
+func (t *Throttler) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timer.stop()
+	t.active = false
+	t.pending = false
+}
+
+// Pending reports whether there is a pending call.
+func (t *Throttler) Pending() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.pending
+}
+
+// fire handles the timer.
+func (t *Throttler) fire() {
+	t.mu.Lock()
+
+	if !t.active {
+		t.mu.Unlock()
+		return
+	}
+
+	now := t.timer.now()
+	if !t.timer.due(now) {
+		t.mu.Unlock()
+		return
+	}
+
+	if !t.pending {
+		t.active = false
+		t.mu.Unlock()
+		return
+	}
+
+	t.pending = false
+	t.start(now)
+	t.mu.Unlock()
+
+	t.fn()
+}
+
+// start starts a new interval.
+func (t *Throttler) start(now time.Duration) {
+	t.timer.arm(now, now+t.interval)
+}
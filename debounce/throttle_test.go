@@ -0,0 +1,70 @@
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/debounce"
+)
+
+func TestThrottler(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	th := debounce.NewThrottler(c, 100*time.Millisecond, calls.inc)
+
+	th.Call()
+	calls.expect(t, 1)
+
+	for i := 0; i < 3; i++ {
+		c.Advance(10 * time.Millisecond)
+		th.Call()
+	}
+	calls.expect(t, 1)
+	if !th.Pending() {
+		t.Fatal("Expected pending call during the interval")
+	}
+
+	c.Advance(70 * time.Millisecond)
+	calls.expect(t, 2)
+
+	c.Advance(100 * time.Millisecond)
+	calls.expect(t, 2)
+	if n := c.WaitersCount(); n != 0 {
+		t.Fatalf("Unexpected waiters count after the interval, expected=0, actual=%d", n)
+	}
+
+	th.Call()
+	calls.expect(t, 3)
+}
+
+func TestThrottlerContinuousCalls(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	th := debounce.NewThrottler(c, 100*time.Millisecond, calls.inc)
+
+	for i := 0; i < 50; i++ {
+		th.Call()
+		c.Advance(20 * time.Millisecond)
+	}
+	calls.expect(t, 11)
+}
+
+func TestThrottlerCancel(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithSyncCallbacks())
+	var calls counter
+	th := debounce.NewThrottler(c, 100*time.Millisecond, calls.inc)
+
+	th.Call()
+	th.Call()
+	th.Cancel()
+	if th.Pending() {
+		t.Fatal("Unexpected pending call after cancel")
+	}
+
+	c.Advance(time.Hour)
+	calls.expect(t, 1)
+
+	th.Call()
+	calls.expect(t, 2)
+}
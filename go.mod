@@ -0,0 +1,3 @@
+module github.com/LopatkinEvgeniy/clock
+
+go 1.18
@@ -0,0 +1,86 @@
+// Package testrecorder provides a testing.TB double for the module's own tests.
+package testrecorder
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Recorder is a testing.TB that records failures and logs instead of reporting them.
+// It lets tests verify how clock helpers fail.
+// Fatalf doesn't stop the calling goroutine.
+// Cleanup functions are collected to be called by RunCleanups.
+type Recorder struct {
+	testing.TB
+
+	mu       sync.Mutex
+	failures []string
+	logs     []string
+	cleanups []func()
+}
+
+// New returns a new recorder that delegates other testing.TB methods to t.
+func New(t testing.TB) *Recorder {
+	return &Recorder{TB: t}
+}
+
+// Helper implements testing.TB.
+func (r *Recorder) Helper() {}
+
+// Fatalf records the failure message.
+func (r *Recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+// Errorf records the failure message.
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// Logf records the log message.
+func (r *Recorder) Logf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+// Cleanup collects the cleanup function.
+func (r *Recorder) Cleanup(f func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cleanups = append(r.cleanups, f)
+}
+
+// RunCleanups calls the collected cleanup functions in the reverse order.
+func (r *Recorder) RunCleanups() {
+	r.mu.Lock()
+	cleanups := r.cleanups
+	r.cleanups = nil
+	r.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// Failures returns the recorded failure messages.
+func (r *Recorder) Failures() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.failures...)
+}
+
+// Logs returns the recorded log messages.
+func (r *Recorder) Logs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.logs...)
+}
@@ -0,0 +1,33 @@
+package testrecorder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/LopatkinEvgeniy/clock/internal/testrecorder"
+)
+
+func TestRecorder(t *testing.T) {
+	r := testrecorder.New(t)
+
+	var order []int
+	r.Cleanup(func() { order = append(order, 1) })
+	r.Cleanup(func() { order = append(order, 2) })
+
+	r.Errorf("error %d", 1)
+	r.Fatalf("fatal %d", 2)
+	r.Logf("log %d", 3)
+
+	if expected := []string{"error 1", "fatal 2"}; !reflect.DeepEqual(r.Failures(), expected) {
+		t.Fatalf("Unexpected failures, expected=%q, actual=%q", expected, r.Failures())
+	}
+	if expected := []string{"log 3"}; !reflect.DeepEqual(r.Logs(), expected) {
+		t.Fatalf("Unexpected logs, expected=%q, actual=%q", expected, r.Logs())
+	}
+
+	r.RunCleanups()
+	r.RunCleanups()
+	if expected := []int{2, 1}; !reflect.DeepEqual(order, expected) {
+		t.Fatalf("Unexpected cleanups order, expected=%v, actual=%v", expected, order)
+	}
+}
@@ -0,0 +1,338 @@
+// Package ratelimit provides the token bucket rate limiter driven by the clock.Clock.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// Limit is the maximum frequency of events in events per second.
+// Zero limit allows no events except the initial burst.
+type Limit float64
+
+// Inf is the infinite rate limit, it allows all events.
+const Inf = Limit(math.MaxFloat64)
+
+// Every converts the minimum time interval between events to the Limit.
+func Every(interval time.Duration) Limit {
+	if interval <= 0 {
+		return Inf
+	}
+	return 1 / Limit(interval.Seconds())
+}
+
+// durationFromTokens returns the time needed to accumulate the tokens.
+func (limit Limit) durationFromTokens(tokens float64) time.Duration {
+	if limit <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	seconds := tokens / float64(limit)
+	if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// tokensFromDuration returns the count of tokens accumulated during the duration.
+func (limit Limit) tokensFromDuration(d time.Duration) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return d.Seconds() * float64(limit)
+}
+
+// Limiter is the token bucket rate limiter.
+// The bucket has the burst size and is refilled with limit tokens per second.
+// Initially the bucket is full.
+// All the time measurements are made by the limiter's clock,
+// timestamps are kept as durations elapsed since the limiter's creation.
+type Limiter struct {
+	clock   clock.Clock
+	created time.Time
+
+	mu        sync.Mutex
+	limit     Limit
+	burst     int
+	tokens    float64
+	last      time.Duration
+	lastEvent time.Duration
+}
+
+// NewLimiter returns a new limiter that allows events up to rate r
+// and permits bursts of at most b tokens.
+func NewLimiter(c clock.Clock, r Limit, b int) *Limiter {
+	return &Limiter{
+		clock:   c,
+		created: c.Now(),
+		limit:   r,
+		burst:   b,
+		tokens:  float64(b),
+	}
+}
+
+// Limit returns the maximum overall event rate.
+func (l *Limiter) Limit() Limit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.limit
+}
+
+// Burst returns the maximum burst size.
+func (l *Limiter) Burst() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.burst
+}
+
+// Tokens returns the count of tokens available now.
+// It's negative if there are pending reservations.
+func (l *Limiter) Tokens() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.advance(l.now())
+}
+
+// SetLimit changes the rate limit.
+// Tokens accumulated so far are kept.
+func (l *Limiter) SetLimit(r Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = l.advance(now)
+	l.last = now
+	l.limit = r
+}
+
+// SetBurst changes the maximum burst size.
+func (l *Limiter) SetBurst(b int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = l.advance(now)
+	l.last = now
+	l.burst = b
+	if l.tokens > float64(b) {
+		l.tokens = float64(b)
+	}
+}
+
+// Allow reports whether an event may happen now.
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now.
+// The tokens are consumed if it returns true.
+func (l *Limiter) AllowN(n int) bool {
+	return l.reserveN(n, 0).ok
+}
+
+// Reserve returns a reservation of a single token.
+func (l *Limiter) Reserve() *Reservation {
+	return l.ReserveN(1)
+}
+
+// ReserveN returns a reservation of n tokens.
+// The caller must wait for the reservation's Delay before acting
+// or Cancel the reservation.
+// The reservation isn't OK if n exceeds the limiter's burst.
+func (l *Limiter) ReserveN(n int) *Reservation {
+	return l.reserveN(n, time.Duration(math.MaxInt64))
+}
+
+// Wait blocks until a single event may happen.
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen.
+// It sleeps using the limiter's clock timer.
+// It returns an error if n exceeds the limiter's burst,
+// the context is done or the context's deadline would be exceeded.
+// The context's deadline is measured by the limiter's clock,
+// see clock.WithTimeout.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	limit, burst := l.limit, l.burst
+	l.mu.Unlock()
+
+	if n > burst && limit != Inf {
+		return fmt.Errorf("ratelimit: Wait(n=%d) exceeds limiter's burst %d", n, burst)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = l.clock.Until(deadline)
+	}
+
+	r := l.reserveN(n, maxWait)
+	if !r.ok {
+		return fmt.Errorf("ratelimit: Wait(n=%d) would exceed context deadline", n)
+	}
+
+	delay := r.Delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := l.clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.Chan():
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// now returns the time elapsed since the limiter's creation.
+func (l *Limiter) now() time.Duration {
+	return l.clock.Since(l.created)
+}
+
+// advance returns the count of tokens accumulated by the specified time.
+// Lock required.
+func (l *Limiter) advance(now time.Duration) float64 {
+	last := l.last
+	if now < last {
+		last = now
+	}
+
+	tokens := l.tokens + l.limit.tokensFromDuration(now-last)
+	if burst := float64(l.burst); tokens > burst {
+		tokens = burst
+	}
+
+	return tokens
+}
+
+// reserveN reserves n tokens if they're available within maxWait.
+func (l *Limiter) reserveN(n int, maxWait time.Duration) *Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if l.limit == Inf {
+		return &Reservation{
+			ok:        true,
+			lim:       l,
+			tokens:    n,
+			timeToAct: now,
+			limit:     l.limit,
+		}
+	}
+
+	tokens := l.advance(now) - float64(n)
+
+	var wait time.Duration
+	if tokens < 0 {
+		wait = l.limit.durationFromTokens(-tokens)
+	}
+
+	if n > l.burst || wait > maxWait || (tokens < 0 && l.limit <= 0) {
+		return &Reservation{
+			lim:   l,
+			limit: l.limit,
+		}
+	}
+
+	r := &Reservation{
+		ok:        true,
+		lim:       l,
+		tokens:    n,
+		timeToAct: now + wait,
+		limit:     l.limit,
+	}
+
+	l.last = now
+	l.tokens = tokens
+	l.lastEvent = r.timeToAct
+
+	return r
+}
+
+// Reservation holds the tokens reserved by the limiter
+// for the events that may happen after a delay.
+type Reservation struct {
+	ok        bool
+	lim       *Limiter
+	tokens    int
+	timeToAct time.Duration
+	limit     Limit
+}
+
+// OK reports whether the limiter can provide the requested tokens.
+// Delay and Cancel have no effect if it's false.
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns the duration to wait before acting on the reservation.
+// Zero means the events may happen immediately.
+func (r *Reservation) Delay() time.Duration {
+	if !r.ok {
+		return time.Duration(math.MaxInt64)
+	}
+
+	delay := r.timeToAct - r.lim.now()
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// Cancel returns the reserved tokens to the limiter
+// as far as possible without affecting the reservations made after this one.
+// It does nothing if the reservation's time has already come.
+func (r *Reservation) Cancel() {
+	if !r.ok || r.tokens == 0 || r.limit == Inf {
+		return
+	}
+
+	l := r.lim
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if r.timeToAct < now {
+		return
+	}
+
+	// Tokens reserved after this reservation can't be returned.
+	restore := float64(r.tokens) - r.limit.tokensFromDuration(l.lastEvent-r.timeToAct)
+	if restore <= 0 {
+		return
+	}
+
+	tokens := l.advance(now) + restore
+	if burst := float64(l.burst); tokens > burst {
+		tokens = burst
+	}
+	l.last = now
+	l.tokens = tokens
+
+	if r.timeToAct == l.lastEvent {
+		prevEvent := r.timeToAct - r.limit.durationFromTokens(float64(r.tokens))
+		if prevEvent >= now {
+			l.lastEvent = prevEvent
+		}
+	}
+
+	r.tokens = 0
+}
@@ -0,0 +1,238 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/ratelimit"
+)
+
+func TestEvery(t *testing.T) {
+	if l := ratelimit.Every(100 * time.Millisecond); l != 10 {
+		t.Fatalf("Unexpected limit, expected=10, actual=%v", l)
+	}
+	if l := ratelimit.Every(0); l != ratelimit.Inf {
+		t.Fatalf("Unexpected limit, expected=Inf, actual=%v", l)
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	c := clock.NewFakeClock()
+	l := ratelimit.NewLimiter(c, ratelimit.Every(time.Second), 3)
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("Expected event %d to be allowed within the burst", i)
+		}
+	}
+	if l.Allow() {
+		t.Fatal("Unexpected event allowed over the burst")
+	}
+
+	c.Advance(999 * time.Millisecond)
+	if l.Allow() {
+		t.Fatal("Unexpected event allowed before the token is accumulated")
+	}
+
+	c.Advance(time.Millisecond)
+	if !l.Allow() {
+		t.Fatal("Expected event to be allowed after the token is accumulated")
+	}
+
+	c.Advance(time.Hour)
+	if tokens := l.Tokens(); tokens != 3 {
+		t.Fatalf("Unexpected tokens count, expected=3, actual=%v", tokens)
+	}
+	if l.AllowN(4) {
+		t.Fatal("Unexpected events allowed over the burst")
+	}
+	if !l.AllowN(3) {
+		t.Fatal("Expected events to be allowed within the burst")
+	}
+}
+
+func TestLimiterZeroLimit(t *testing.T) {
+	c := clock.NewFakeClock()
+	l := ratelimit.NewLimiter(c, 0, 1)
+
+	if !l.Allow() {
+		t.Fatal("Expected the initial burst to be allowed")
+	}
+	c.Advance(time.Hour)
+	if l.Allow() {
+		t.Fatal("Unexpected event allowed with zero limit")
+	}
+	if l.Reserve().OK() {
+		t.Fatal("Unexpected OK reservation with zero limit")
+	}
+}
+
+func TestLimiterInf(t *testing.T) {
+	c := clock.NewFakeClock()
+	l := ratelimit.NewLimiter(c, ratelimit.Inf, 0)
+
+	for i := 0; i < 100; i++ {
+		if !l.Allow() {
+			t.Fatal("Expected event to be allowed with infinite limit")
+		}
+	}
+	if err := l.WaitN(context.Background(), 100); err != nil {
+		t.Fatalf("Unexpected WaitN error: %s", err)
+	}
+}
+
+func TestLimiterCancelInfReservation(t *testing.T) {
+	c := clock.NewFakeClock()
+	l := ratelimit.NewLimiter(c, 1, 3)
+
+	if !l.AllowN(3) {
+		t.Fatal("Expected events to be allowed within the burst")
+	}
+
+	l.SetLimit(ratelimit.Inf)
+	l.ReserveN(3).Cancel()
+	l.SetLimit(1)
+
+	if tokens := l.Tokens(); tokens != 0 {
+		t.Fatalf("Unexpected tokens count, expected=0, actual=%v", tokens)
+	}
+}
+
+func TestLimiterReserve(t *testing.T) {
+	c := clock.NewFakeClock()
+	l := ratelimit.NewLimiter(c, 2, 1)
+
+	r := l.Reserve()
+	if !r.OK() || r.Delay() != 0 {
+		t.Fatalf("Unexpected reservation, ok=%t, delay=%s", r.OK(), r.Delay())
+	}
+
+	r = l.Reserve()
+	if !r.OK() || r.Delay() != 500*time.Millisecond {
+		t.Fatalf("Unexpected reservation, ok=%t, delay=%s", r.OK(), r.Delay())
+	}
+
+	r2 := l.Reserve()
+	if !r2.OK() || r2.Delay() != time.Second {
+		t.Fatalf("Unexpected reservation, ok=%t, delay=%s", r2.OK(), r2.Delay())
+	}
+
+	c.Advance(200 * time.Millisecond)
+	if d := r.Delay(); d != 300*time.Millisecond {
+		t.Fatalf("Unexpected delay, expected=300ms, actual=%s", d)
+	}
+
+	r2.Cancel()
+	r3 := l.Reserve()
+	if d := r3.Delay(); d != 800*time.Millisecond {
+		t.Fatalf("Unexpected delay after cancel, expected=800ms, actual=%s", d)
+	}
+
+	if l.ReserveN(2).OK() {
+		t.Fatal("Unexpected OK reservation over the burst")
+	}
+}
+
+func TestLimiterSetLimit(t *testing.T) {
+	c := clock.NewFakeClock()
+	l := ratelimit.NewLimiter(c, 1, 10)
+	l.AllowN(10)
+
+	c.Advance(2 * time.Second)
+	l.SetLimit(10)
+	if limit := l.Limit(); limit != 10 {
+		t.Fatalf("Unexpected limit, expected=10, actual=%v", limit)
+	}
+
+	c.Advance(500 * time.Millisecond)
+	if tokens := l.Tokens(); tokens != 7 {
+		t.Fatalf("Unexpected tokens count, expected=7, actual=%v", tokens)
+	}
+
+	l.SetBurst(5)
+	if burst := l.Burst(); burst != 5 {
+		t.Fatalf("Unexpected burst, expected=5, actual=%d", burst)
+	}
+	if tokens := l.Tokens(); tokens != 5 {
+		t.Fatalf("Unexpected tokens count, expected=5, actual=%v", tokens)
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	t.Run("wait for tokens", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		l := ratelimit.NewLimiter(c, ratelimit.Every(time.Minute), 1)
+
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected Wait error: %s", err)
+		}
+
+		errCh := make(chan error)
+		go func() {
+			errCh <- l.Wait(context.Background())
+		}()
+
+		c.BlockUntil(1)
+		waiters := c.Waiters()
+		if waiters[0].Duration != time.Minute {
+			t.Fatalf("Unexpected wait duration, expected=1m, actual=%s", waiters[0].Duration)
+		}
+
+		c.Advance(time.Minute)
+		if err := <-errCh; err != nil {
+			t.Fatalf("Unexpected Wait error: %s", err)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		l := ratelimit.NewLimiter(c, ratelimit.Every(time.Minute), 1)
+		l.Allow()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error)
+		go func() {
+			errCh <- l.Wait(ctx)
+		}()
+
+		c.BlockUntil(1)
+		cancel()
+		if err := <-errCh; err != context.Canceled {
+			t.Fatalf("Unexpected Wait error, expected=%v, actual=%v", context.Canceled, err)
+		}
+		c.BlockUntil(0)
+
+		// The cancelled reservation's token is returned.
+		c.Advance(time.Minute)
+		if !l.Allow() {
+			t.Fatal("Expected event to be allowed")
+		}
+	})
+
+	t.Run("deadline would be exceeded", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		l := ratelimit.NewLimiter(c, ratelimit.Every(time.Minute), 1)
+		l.Allow()
+
+		ctx, cancel := clock.WithTimeout(c, context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := l.Wait(ctx); err == nil {
+			t.Fatal("Expected Wait error")
+		}
+		if tokens := l.Tokens(); tokens != 0 {
+			t.Fatalf("Unexpected tokens count, expected=0, actual=%v", tokens)
+		}
+	})
+
+	t.Run("exceeds burst", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		l := ratelimit.NewLimiter(c, 1, 1)
+
+		if err := l.WaitN(context.Background(), 2); err == nil {
+			t.Fatal("Expected WaitN error")
+		}
+	})
+}
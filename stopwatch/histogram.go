@@ -0,0 +1,104 @@
+package stopwatch
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultBuckets are the default upper bounds of the histogram's buckets.
+var DefaultBuckets = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Histogram aggregates durations into buckets.
+// The i-th bucket counts durations in (Bounds[i-1], Bounds[i]],
+// and the last bucket counts durations greater than all bounds.
+type Histogram struct {
+	Bounds []time.Duration
+	Counts []int
+	Count  int
+	Sum    time.Duration
+	Min    time.Duration
+	Max    time.Duration
+}
+
+// NewHistogram returns a new empty histogram with the specified buckets' upper bounds.
+func NewHistogram(bounds ...time.Duration) *Histogram {
+	bounds = append([]time.Duration(nil), bounds...)
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	return &Histogram{
+		Bounds: bounds,
+		Counts: make([]int, len(bounds)+1),
+	}
+}
+
+// Observe adds the duration to the histogram.
+func (h *Histogram) Observe(d time.Duration) {
+	i := sort.Search(len(h.Bounds), func(i int) bool { return d <= h.Bounds[i] })
+	h.Counts[i]++
+
+	if h.Count == 0 || d < h.Min {
+		h.Min = d
+	}
+	if h.Count == 0 || d > h.Max {
+		h.Max = d
+	}
+	h.Count++
+	h.Sum += d
+}
+
+// Mean returns the mean duration, it's zero for the empty histogram.
+func (h *Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Quantile estimates the q-quantile as the upper bound of the bucket containing it
+// limited by the maximum duration. It's zero for the empty histogram.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(q * float64(h.Count)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	seen := 0
+	for i, n := range h.Counts {
+		seen += n
+		if seen < rank {
+			continue
+		}
+		if i < len(h.Bounds) && h.Bounds[i] < h.Max {
+			return h.Bounds[i]
+		}
+		break
+	}
+	return h.Max
+}
+
+// clone returns the histogram's copy.
+func (h *Histogram) clone() *Histogram {
+	c := *h
+	c.Bounds = append([]time.Duration(nil), h.Bounds...)
+	c.Counts = append([]int(nil), h.Counts...)
+	return &c
+}
@@ -0,0 +1,72 @@
+package stopwatch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock/stopwatch"
+)
+
+func TestHistogramEmpty(t *testing.T) {
+	h := stopwatch.NewHistogram(stopwatch.DefaultBuckets...)
+
+	if mean := h.Mean(); mean != 0 {
+		t.Fatalf("Unexpected mean, expected=0, actual=%s", mean)
+	}
+	if q := h.Quantile(0.5); q != 0 {
+		t.Fatalf("Unexpected quantile, expected=0, actual=%s", q)
+	}
+}
+
+func TestHistogramObserve(t *testing.T) {
+	h := stopwatch.NewHistogram(10*time.Millisecond, 100*time.Millisecond, time.Second)
+
+	for _, d := range []time.Duration{
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		30 * time.Millisecond,
+		2 * time.Second,
+	} {
+		h.Observe(d)
+	}
+
+	if h.Count != 5 {
+		t.Fatalf("Unexpected count, expected=5, actual=%d", h.Count)
+	}
+	if h.Min != 5*time.Millisecond {
+		t.Fatalf("Unexpected min, expected=%s, actual=%s", 5*time.Millisecond, h.Min)
+	}
+	if h.Max != 2*time.Second {
+		t.Fatalf("Unexpected max, expected=%s, actual=%s", 2*time.Second, h.Max)
+	}
+	if mean := h.Mean(); mean != 413*time.Millisecond {
+		t.Fatalf("Unexpected mean, expected=%s, actual=%s", 413*time.Millisecond, mean)
+	}
+
+	cases := []struct {
+		q        float64
+		expected time.Duration
+	}{
+		{0, 10 * time.Millisecond},
+		{0.4, 10 * time.Millisecond},
+		{0.5, 100 * time.Millisecond},
+		{0.8, 100 * time.Millisecond},
+		{0.9, 2 * time.Second},
+		{1, 2 * time.Second},
+	}
+	for _, tc := range cases {
+		if q := h.Quantile(tc.q); q != tc.expected {
+			t.Fatalf("Unexpected quantile %v, expected=%s, actual=%s", tc.q, tc.expected, q)
+		}
+	}
+}
+
+func TestHistogramQuantileLimitedByMax(t *testing.T) {
+	h := stopwatch.NewHistogram(time.Second)
+	h.Observe(300 * time.Millisecond)
+
+	if q := h.Quantile(0.99); q != 300*time.Millisecond {
+		t.Fatalf("Unexpected quantile, expected=%s, actual=%s", 300*time.Millisecond, q)
+	}
+}
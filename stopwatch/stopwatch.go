@@ -0,0 +1,174 @@
+// Package stopwatch measures the elapsed time and laps with the clock.Clock.
+package stopwatch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+// state is the stopwatch's state.
+type state int
+
+const (
+	idle state = iota
+	running
+	paused
+	stopped
+)
+
+// Option configures the Stopwatch.
+type Option func(*Stopwatch)
+
+// WithBuckets sets the upper bounds of the laps histogram's buckets.
+// DefaultBuckets are used by default.
+func WithBuckets(bounds ...time.Duration) Option {
+	return func(s *Stopwatch) {
+		s.bounds = bounds
+	}
+}
+
+// Stopwatch measures the elapsed time and laps.
+// The time while the stopwatch is paused isn't counted.
+// It's safe for concurrent use.
+type Stopwatch struct {
+	clock   clock.Clock
+	created time.Time
+	bounds  []time.Duration
+
+	mu       sync.Mutex
+	state    state
+	resumed  time.Duration
+	elapsed  time.Duration
+	lapStart time.Duration
+	laps     []time.Duration
+	hist     *Histogram
+}
+
+// New returns a new stopwatch that isn't started.
+func New(c clock.Clock, opts ...Option) *Stopwatch {
+	s := &Stopwatch{
+		clock:   c,
+		created: c.Now(),
+		bounds:  DefaultBuckets,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.hist = NewHistogram(s.bounds...)
+	return s
+}
+
+// Start resets the stopwatch and starts it.
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = running
+	s.resumed = s.now()
+	s.elapsed = 0
+	s.lapStart = 0
+	s.laps = nil
+	s.hist = NewHistogram(s.bounds...)
+}
+
+// Stop stops the running or paused stopwatch and returns the elapsed time.
+// The stopped stopwatch can only be started again.
+func (s *Stopwatch) Stop() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == running {
+		s.elapsed = s.total()
+	}
+	if s.state == running || s.state == paused {
+		s.state = stopped
+	}
+	return s.elapsed
+}
+
+// Pause pauses the running stopwatch.
+func (s *Stopwatch) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == running {
+		s.elapsed = s.total()
+		s.state = paused
+	}
+}
+
+// Resume resumes the paused stopwatch.
+func (s *Stopwatch) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == paused {
+		s.resumed = s.now()
+		s.state = running
+	}
+}
+
+// Running reports whether the stopwatch is running.
+func (s *Stopwatch) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state == running
+}
+
+// Elapsed returns the total elapsed time.
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.total()
+}
+
+// Lap records and returns the time elapsed since the previous lap or the start.
+// It records nothing and returns zero if the stopwatch isn't running or paused.
+func (s *Stopwatch) Lap() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != running && s.state != paused {
+		return 0
+	}
+
+	total := s.total()
+	lap := total - s.lapStart
+	s.lapStart = total
+	s.laps = append(s.laps, lap)
+	s.hist.Observe(lap)
+	return lap
+}
+
+// Laps returns the recorded laps.
+func (s *Stopwatch) Laps() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]time.Duration(nil), s.laps...)
+}
+
+// Histogram returns the histogram of the recorded laps.
+func (s *Stopwatch) Histogram() *Histogram {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.hist.clone()
+}
+
+// total returns the total elapsed time.
+func (s *Stopwatch) total() time.Duration {
+	if s.state != running {
+		return s.elapsed
+	}
+	return s.elapsed + s.now() - s.resumed
+}
+
+// now returns the time elapsed since the stopwatch's creation.
+func (s *Stopwatch) now() time.Duration {
+	return s.clock.Since(s.created)
+}
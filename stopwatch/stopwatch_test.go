@@ -0,0 +1,138 @@
+package stopwatch_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+	"github.com/LopatkinEvgeniy/clock/stopwatch"
+)
+
+func TestStopwatch(t *testing.T) {
+	c := clock.NewFakeClock()
+	s := stopwatch.New(c)
+
+	c.Advance(time.Hour)
+	if elapsed := s.Elapsed(); elapsed != 0 {
+		t.Fatalf("Unexpected elapsed time before start, expected=0, actual=%s", elapsed)
+	}
+
+	s.Start()
+	if !s.Running() {
+		t.Fatal("Expected stopwatch to be running after start")
+	}
+	c.Advance(1500 * time.Millisecond)
+	if elapsed := s.Elapsed(); elapsed != 1500*time.Millisecond {
+		t.Fatalf("Unexpected elapsed time, expected=%s, actual=%s", 1500*time.Millisecond, elapsed)
+	}
+
+	if elapsed := s.Stop(); elapsed != 1500*time.Millisecond {
+		t.Fatalf("Unexpected elapsed time on stop, expected=%s, actual=%s", 1500*time.Millisecond, elapsed)
+	}
+	if s.Running() {
+		t.Fatal("Unexpected running stopwatch after stop")
+	}
+
+	c.Advance(time.Hour)
+	s.Resume()
+	if elapsed := s.Elapsed(); elapsed != 1500*time.Millisecond {
+		t.Fatalf("Unexpected elapsed time after stop, expected=%s, actual=%s", 1500*time.Millisecond, elapsed)
+	}
+
+	s.Start()
+	c.Advance(time.Second)
+	if elapsed := s.Elapsed(); elapsed != time.Second {
+		t.Fatalf("Unexpected elapsed time after restart, expected=%s, actual=%s", time.Second, elapsed)
+	}
+}
+
+func TestStopwatchPause(t *testing.T) {
+	c := clock.NewFakeClock()
+	s := stopwatch.New(c)
+
+	s.Start()
+	c.Advance(time.Second)
+	s.Pause()
+	c.Advance(time.Hour)
+	if elapsed := s.Elapsed(); elapsed != time.Second {
+		t.Fatalf("Unexpected elapsed time while paused, expected=%s, actual=%s", time.Second, elapsed)
+	}
+
+	s.Resume()
+	c.Advance(2 * time.Second)
+	if elapsed := s.Stop(); elapsed != 3*time.Second {
+		t.Fatalf("Unexpected elapsed time, expected=%s, actual=%s", 3*time.Second, elapsed)
+	}
+}
+
+func TestStopwatchLaps(t *testing.T) {
+	c := clock.NewFakeClock()
+	s := stopwatch.New(c)
+
+	if lap := s.Lap(); lap != 0 {
+		t.Fatalf("Unexpected lap before start, expected=0, actual=%s", lap)
+	}
+
+	s.Start()
+	c.Advance(100 * time.Millisecond)
+	if lap := s.Lap(); lap != 100*time.Millisecond {
+		t.Fatalf("Unexpected lap, expected=%s, actual=%s", 100*time.Millisecond, lap)
+	}
+
+	c.Advance(200 * time.Millisecond)
+	s.Pause()
+	c.Advance(time.Hour)
+	s.Resume()
+	c.Advance(50 * time.Millisecond)
+	if lap := s.Lap(); lap != 250*time.Millisecond {
+		t.Fatalf("Unexpected lap with pause, expected=%s, actual=%s", 250*time.Millisecond, lap)
+	}
+
+	c.Advance(30 * time.Millisecond)
+	s.Stop()
+	if lap := s.Lap(); lap != 0 {
+		t.Fatalf("Unexpected lap after stop, expected=0, actual=%s", lap)
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 250 * time.Millisecond}
+	if laps := s.Laps(); !reflect.DeepEqual(laps, expected) {
+		t.Fatalf("Unexpected laps, expected=%v, actual=%v", expected, laps)
+	}
+	if elapsed := s.Elapsed(); elapsed != 380*time.Millisecond {
+		t.Fatalf("Unexpected elapsed time, expected=%s, actual=%s", 380*time.Millisecond, elapsed)
+	}
+
+	h := s.Histogram()
+	if h.Count != 2 || h.Sum != 350*time.Millisecond {
+		t.Fatalf("Unexpected histogram, expected count=2 sum=%s, actual count=%d sum=%s", 350*time.Millisecond, h.Count, h.Sum)
+	}
+
+	s.Start()
+	if laps := s.Laps(); len(laps) != 0 {
+		t.Fatalf("Unexpected laps after restart, expected=[], actual=%v", laps)
+	}
+	if h := s.Histogram(); h.Count != 0 {
+		t.Fatalf("Unexpected histogram count after restart, expected=0, actual=%d", h.Count)
+	}
+}
+
+func TestStopwatchBuckets(t *testing.T) {
+	c := clock.NewFakeClock()
+	s := stopwatch.New(c, stopwatch.WithBuckets(time.Second, 100*time.Millisecond))
+
+	s.Start()
+	for _, d := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 500 * time.Millisecond, 2 * time.Second} {
+		c.Advance(d)
+		s.Lap()
+	}
+
+	h := s.Histogram()
+	expectedBounds := []time.Duration{100 * time.Millisecond, time.Second}
+	if !reflect.DeepEqual(h.Bounds, expectedBounds) {
+		t.Fatalf("Unexpected bounds, expected=%v, actual=%v", expectedBounds, h.Bounds)
+	}
+	if expected := []int{2, 1, 1}; !reflect.DeepEqual(h.Counts, expected) {
+		t.Fatalf("Unexpected counts, expected=%v, actual=%v", expected, h.Counts)
+	}
+}
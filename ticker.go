@@ -1,10 +1,14 @@
 package clock
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // Ticker is an interface that represents both real and mock tickers.
 type Ticker interface {
 	Chan() <-chan time.Time
+	Reset(d time.Duration)
 	Stop()
 }
 
@@ -35,3 +39,14 @@ func (t mockTicker) Chan() <-chan time.Time {
 func (t mockTicker) Stop() {
 	t.clock.stopTimer(t.internalTimer)
 }
+
+// Reset implements Ticker.
+// The next tick would be triggered after the new duration.
+// A tick that is not received yet stays in the channel,
+// unless the clock uses Go 1.23 timers, see WithGo123Timers.
+func (t mockTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic(errors.New("non-positive interval for Ticker.Reset"))
+	}
+	t.clock.resetTimer(t.internalTimer, d)
+}
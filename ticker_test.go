@@ -1,6 +1,7 @@
 package clock_test
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -45,7 +46,7 @@ func TestFakeTickerChanStress(t *testing.T) {
 				c.Advance(time.Second)
 				select {
 				case <-ticker.Chan():
-					t.Fatal("Unexpected ticker's channel receive")
+					t.Error("Unexpected ticker's channel receive")
 				default:
 				}
 			}()
@@ -171,3 +172,256 @@ func TestFakeTickerPanicsOnNonPositiveInterval(t *testing.T) {
 		c.NewTicker(-1)
 	})
 }
+
+func TestFakeTickerCatchUp(t *testing.T) {
+	checkTicks := func(t *testing.T, c clock.FakeClock, ticker clock.Ticker, d time.Duration, n int) {
+		start := c.Now()
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			c.Advance(time.Duration(n) * d)
+		}()
+
+		for i := 1; i <= n; i++ {
+			expectedTime := start.Add(time.Duration(i) * d)
+			actualTime := <-ticker.Chan()
+			if expectedTime != actualTime {
+				t.Fatalf("Unexpected time received from the ticker's channel, expected=%s, actual=%s", expectedTime, actualTime)
+			}
+		}
+		<-doneCh
+
+		select {
+		case <-ticker.Chan():
+			t.Fatal("Unexpected ticker's channel receive")
+		default:
+		}
+	}
+
+	t.Run("clock option", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithTickerCatchUp())
+		ticker := c.NewTicker(time.Second)
+
+		checkTicks(t, c, ticker, time.Second, 10)
+		checkTicks(t, c, ticker, time.Second, 5)
+	})
+
+	t.Run("labeled ticker", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithTickerCatchUpLabels("poll"))
+		ticker := clock.Labeled(c, "poll").NewTicker(time.Second)
+		regularTicker := c.NewTicker(time.Second)
+
+		checkTicks(t, c, ticker, time.Second, 10)
+
+		expectedTime := (time.Time{}).Add(time.Second)
+		actualTime := <-regularTicker.Chan()
+		if expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the ticker's channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+		select {
+		case <-regularTicker.Chan():
+			t.Fatal("Unexpected ticker's channel receive")
+		default:
+		}
+	})
+
+	t.Run("component's own ticker", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithTickerCatchUpLabels("poll"))
+
+		// poll counts the ticks of the ticker it owns until the context is done.
+		poll := func(ctx context.Context, clk clock.Clock, polls chan<- time.Time) {
+			ticker := clock.Labeled(clk, "poll").NewTicker(time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case tm := <-ticker.Chan():
+					polls <- tm
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		polls := make(chan time.Time)
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			poll(ctx, c, polls)
+		}()
+
+		c.BlockUntilLabel("poll")
+		advanceDone := make(chan struct{})
+		go func() {
+			defer close(advanceDone)
+			c.Advance(5 * time.Second)
+		}()
+
+		for i := 1; i <= 5; i++ {
+			expectedTime := (time.Time{}).Add(time.Duration(i) * time.Second)
+			if actualTime := <-polls; expectedTime != actualTime {
+				t.Fatalf("Unexpected poll time, expected=%s, actual=%s", expectedTime, actualTime)
+			}
+		}
+		<-advanceDone
+		cancel()
+		<-doneCh
+	})
+
+	t.Run("tick doesn't catch up", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithTickerCatchUp())
+		ch := c.Tick(time.Second)
+
+		c.Advance(time.Second)
+		c.Advance(3 * time.Second)
+
+		expectedTime := (time.Time{}).Add(time.Second)
+		if actualTime := <-ch; expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the tick channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+	})
+
+	t.Run("stop while waiting for the consumer", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithTickerCatchUp())
+		ticker := c.NewTicker(time.Second)
+
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			c.Advance(time.Hour)
+		}()
+
+		for i := 0; i < 3; i++ {
+			<-ticker.Chan()
+		}
+		ticker.Stop()
+		<-doneCh
+
+		expectedNow := (time.Time{}).Add(time.Hour)
+		if now := c.Now(); now != expectedNow {
+			t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedNow, now)
+		}
+	})
+}
+
+func TestFakeTickerReset(t *testing.T) {
+	t.Run("change interval", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		ticker := c.NewTicker(time.Hour)
+
+		c.Advance(30 * time.Minute)
+		ticker.Reset(time.Minute)
+
+		for i := 1; i <= 10; i++ {
+			c.Advance(time.Minute)
+			expectedTime := (time.Time{}).Add(30*time.Minute + time.Duration(i)*time.Minute)
+			actualTime := <-ticker.Chan()
+			if expectedTime != actualTime {
+				t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+			}
+		}
+	})
+
+	t.Run("tick is buffered", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		ticker := c.NewTicker(time.Minute)
+
+		c.Advance(time.Minute)
+		ticker.Reset(10 * time.Minute)
+
+		expectedTime := (time.Time{}).Add(time.Minute)
+		actualTime := <-ticker.Chan()
+		if expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+
+		c.Advance(9 * time.Minute)
+		select {
+		case <-ticker.Chan():
+			t.Fatal("Unexpected ticker's channel receive")
+		default:
+		}
+
+		c.Advance(time.Minute)
+		expectedTime = (time.Time{}).Add(11 * time.Minute)
+		actualTime = <-ticker.Chan()
+		if expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+	})
+
+	t.Run("reset stopped ticker", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		ticker := c.NewTicker(time.Minute)
+
+		ticker.Stop()
+		ticker.Reset(time.Minute)
+		if n := c.WaitersCount(); n != 1 {
+			t.Fatalf("Unexpected waiters count, expected=1, actual=%d", n)
+		}
+
+		c.Advance(time.Minute)
+		select {
+		case <-ticker.Chan():
+		default:
+			t.Fatal("Expected receive from the ticker's channel")
+		}
+	})
+
+	t.Run("non-positive interval", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		ticker := c.NewTicker(time.Minute)
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		ticker.Reset(0)
+	})
+}
+
+func TestRealTickerReset(t *testing.T) {
+	ticker := clock.NewRealClock().NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	ticker.Reset(time.Millisecond)
+	<-ticker.Chan()
+}
+
+func TestFakeTickerGo123Semantics(t *testing.T) {
+	t.Run("stop", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithGo123Timers())
+		ticker := c.NewTicker(time.Minute)
+
+		c.Advance(time.Minute)
+		ticker.Stop()
+
+		select {
+		case <-ticker.Chan():
+			t.Fatal("Unexpected stale tick received from the ticker's channel")
+		default:
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithGo123Timers())
+		ticker := c.NewTicker(time.Minute)
+
+		c.Advance(time.Minute)
+		ticker.Reset(10 * time.Minute)
+
+		select {
+		case <-ticker.Chan():
+			t.Fatal("Unexpected stale tick received from the ticker's channel")
+		default:
+		}
+
+		c.Advance(10 * time.Minute)
+		expectedTime := (time.Time{}).Add(11 * time.Minute)
+		actualTime := <-ticker.Chan()
+		if expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+	})
+}
@@ -5,6 +5,9 @@ import (
 )
 
 // Timer is an interface that represents both real and mock timers.
+// For the timers created by AfterFunc Chan receives
+// the time the timer fired at once the callback returns,
+// so it can be used to wait for the callback completion.
 type Timer interface {
 	Chan() <-chan time.Time
 	Reset(d time.Duration) bool
@@ -15,13 +18,16 @@ var _ Timer = realTimer{}
 var _ Timer = mockTimer{}
 
 // realTimer is just a time.Timer's shallow wrapper.
+// The channel is either the timer's own one
+// or the callback completion channel for AfterFunc timers.
 type realTimer struct {
 	*time.Timer
+	ch <-chan time.Time
 }
 
 // Chan implements Timer.
 func (t realTimer) Chan() <-chan time.Time {
-	return t.C
+	return t.ch
 }
 
 // mockTimer is just an internalTimer's shallow wrapper.
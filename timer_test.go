@@ -43,7 +43,7 @@ func TestFakeTimerChanStress(t *testing.T) {
 			c.Advance(time.Second)
 			select {
 			case <-timer.Chan():
-				t.Fatal("Unexpected timer's channel receive")
+				t.Error("Unexpected timer's channel receive")
 			default:
 			}
 		}()
@@ -239,3 +239,140 @@ func TestFakeTimerStopStress(t *testing.T) {
 		}
 	}
 }
+
+func TestFakeTimerGo123Semantics(t *testing.T) {
+	t.Run("stop expired timer", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithGo123Timers())
+		timer := c.NewTimer(time.Minute)
+
+		c.Advance(time.Minute)
+
+		wasActive := timer.Stop()
+		if !wasActive {
+			t.Fatal("Unexpected stop result value")
+		}
+
+		select {
+		case <-timer.Chan():
+			t.Fatal("Unexpected stale value received from the timer's channel")
+		default:
+		}
+
+		wasActive = timer.Stop()
+		if wasActive {
+			t.Fatal("Unexpected stop result value")
+		}
+	})
+
+	t.Run("reset expired timer", func(t *testing.T) {
+		c := clock.NewFakeClock(clock.WithGo123Timers())
+		timer := c.NewTimer(time.Minute)
+
+		c.Advance(time.Minute)
+
+		wasActive := timer.Reset(time.Minute)
+		if !wasActive {
+			t.Fatal("Unexpected reset result value")
+		}
+
+		select {
+		case <-timer.Chan():
+			t.Fatal("Unexpected stale value received from the timer's channel")
+		default:
+		}
+
+		c.Advance(time.Minute)
+		expectedTime := (time.Time{}).Add(2 * time.Minute)
+		actualTime := <-timer.Chan()
+		if expectedTime != actualTime {
+			t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+		}
+
+		wasActive = timer.Reset(time.Minute)
+		if wasActive {
+			t.Fatal("Unexpected reset result value")
+		}
+	})
+
+	t.Run("legacy semantics", func(t *testing.T) {
+		c := clock.NewFakeClock()
+		timer := c.NewTimer(time.Minute)
+
+		c.Advance(time.Minute)
+
+		wasActive := timer.Stop()
+		if wasActive {
+			t.Fatal("Unexpected stop result value")
+		}
+
+		select {
+		case <-timer.Chan():
+		default:
+			t.Fatal("Expected receive from the timer's channel")
+		}
+	})
+}
+
+// clockCase is a clock implementation with the way to let its time pass.
+type clockCase struct {
+	name    string
+	clock   clock.Clock
+	advance func(d time.Duration)
+}
+
+func TestAfterFuncTimerChan(t *testing.T) {
+	fakeClock := clock.NewFakeClock()
+	clocks := []clockCase{
+		{name: "real clock", clock: clock.NewRealClock(), advance: func(time.Duration) {}},
+		{name: "fake clock", clock: fakeClock, advance: fakeClock.Advance},
+	}
+
+	for _, tc := range clocks {
+		tc := tc
+
+		t.Run(tc.name+" callback done", func(t *testing.T) {
+			d := 10 * time.Millisecond
+			start := tc.clock.Now()
+
+			var done bool
+			timer := tc.clock.AfterFunc(d, func() {
+				time.Sleep(d)
+				done = true
+			})
+			if timer.Chan() == nil {
+				t.Fatal("Unexpected nil channel")
+			}
+
+			tc.advance(d)
+
+			select {
+			case firedAt := <-timer.Chan():
+				if !done {
+					t.Fatal("Channel receive before the callback is done")
+				}
+				if firedAt.Before(start.Add(d)) {
+					t.Fatalf("Unexpected time received from the channel, expected not before %s, actual=%s", start.Add(d), firedAt)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Expected receive from the timer's channel")
+			}
+		})
+
+		t.Run(tc.name+" stopped", func(t *testing.T) {
+			d := 10 * time.Millisecond
+
+			timer := tc.clock.AfterFunc(d, func() {})
+			if !timer.Stop() {
+				t.Fatal("Unexpected stop result value")
+			}
+
+			tc.advance(d)
+
+			select {
+			case <-timer.Chan():
+				t.Fatal("Unexpected timer's channel receive")
+			case <-time.After(5 * d):
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package clock
+
+import "container/heap"
+
+// timerHeap is a priority queue of the registered timers
+// ordered by the trigger time and the creation order.
+// It implements heap.Interface, every timer keeps its own index.
+type timerHeap []*internalTimer
+
+var _ heap.Interface = (*timerHeap)(nil)
+
+// Len implements heap.Interface.
+func (h timerHeap) Len() int {
+	return len(h)
+}
+
+// Less implements heap.Interface.
+func (h timerHeap) Less(i, j int) bool {
+	return h[i].before(h[j])
+}
+
+// Swap implements heap.Interface.
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+// Push implements heap.Interface.
+func (h *timerHeap) Push(x interface{}) {
+	t := x.(*internalTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+// Pop implements heap.Interface.
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
+
+// peek returns the earliest timer or nil if the heap is empty.
+func (h timerHeap) peek() *internalTimer {
+	if len(h) == 0 {
+		return nil
+	}
+	return h[0]
+}
@@ -0,0 +1,198 @@
+package clock
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WaiterKind is a kind of the fake clock's waiter.
+type WaiterKind int
+
+// Fake clock's waiter kinds.
+const (
+	// KindTimer is a timer created by NewTimer.
+	KindTimer WaiterKind = iota
+	// KindTicker is a ticker created by NewTicker.
+	KindTicker
+	// KindAfterFunc is a timer created by AfterFunc.
+	KindAfterFunc
+	// KindSleeper is a goroutine blocked in Sleep.
+	KindSleeper
+	// KindTick is a ticker created by Tick.
+	KindTick
+	// KindAfter is a timer created by After.
+	KindAfter
+)
+
+// String implements fmt.Stringer.
+func (k WaiterKind) String() string {
+	switch k {
+	case KindTimer:
+		return "timer"
+	case KindTicker:
+		return "ticker"
+	case KindAfterFunc:
+		return "AfterFunc"
+	case KindSleeper:
+		return "sleeper"
+	case KindTick:
+		return "Tick"
+	case KindAfter:
+		return "After"
+	default:
+		return "unknown"
+	}
+}
+
+// WaiterAction is an action happened to the fake clock's waiter.
+type WaiterAction int
+
+// Fake clock's waiter actions.
+const (
+	// ActionRegistered means a new waiter was created.
+	ActionRegistered WaiterAction = iota
+	// ActionStopped means an active waiter was stopped.
+	ActionStopped
+	// ActionReset means a waiter was reset with a new duration.
+	ActionReset
+	// ActionFired means a waiter was triggered by the clock.
+	ActionFired
+)
+
+// String implements fmt.Stringer.
+func (a WaiterAction) String() string {
+	switch a {
+	case ActionRegistered:
+		return "registered"
+	case ActionStopped:
+		return "stopped"
+	case ActionReset:
+		return "reset"
+	case ActionFired:
+		return "fired"
+	default:
+		return "unknown"
+	}
+}
+
+// WaiterEvent describes a change of the fake clock's waiter.
+type WaiterEvent struct {
+	Kind        WaiterKind
+	Label       string
+	Action      WaiterAction
+	Duration    time.Duration
+	TriggerTime time.Time
+}
+
+// WaiterInfo describes an active fake clock's waiter.
+type WaiterInfo struct {
+	Kind WaiterKind
+	// Label is the label of the clock the waiter was created by, see Labeled.
+	Label       string
+	Duration    time.Duration
+	TriggerTime time.Time
+	// CallSite is the file:line where the waiter was created.
+	// It's empty unless the creation stacks are captured, see WithCreationStacks.
+	CallSite string
+
+	stack []uintptr
+}
+
+// String implements fmt.Stringer.
+func (w WaiterInfo) String() string {
+	s := fmt.Sprintf("%s: duration=%s trigger=%s", w.Kind, w.Duration, w.TriggerTime)
+	if w.Label != "" {
+		s = fmt.Sprintf("%s %q: duration=%s trigger=%s", w.Kind, w.Label, w.Duration, w.TriggerTime)
+	}
+	if w.CallSite != "" {
+		s += " created at " + w.CallSite
+	}
+	return s
+}
+
+// Stack returns the stack trace captured when the waiter was created.
+// Frames of the clock package itself are omitted.
+// It's empty unless the creation stacks are captured, see WithCreationStacks.
+func (w WaiterInfo) Stack() string {
+	if len(w.stack) == 0 {
+		return ""
+	}
+
+	b := strings.Builder{}
+
+	frames := runtime.CallersFrames(w.stack)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+
+	return b.String()
+}
+
+// subscriber delivers waiter events to the subscription channel.
+// Events are queued without limit, so the clock is never blocked by slow subscribers.
+type subscriber struct {
+	ch    chan WaiterEvent
+	mu    sync.Mutex
+	queue []WaiterEvent
+	wake  chan struct{}
+	done  chan struct{}
+}
+
+// newSubscriber creates a new subscriber and starts its delivery goroutine.
+func newSubscriber() *subscriber {
+	s := &subscriber{
+		ch:   make(chan WaiterEvent),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	go s.run()
+
+	return s
+}
+
+// push enqueues the event for delivery.
+func (s *subscriber) push(e WaiterEvent) {
+	s.mu.Lock()
+	s.queue = append(s.queue, e)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers queued events until the subscriber is closed.
+func (s *subscriber) run() {
+	defer close(s.ch)
+
+	for {
+		s.mu.Lock()
+		queue := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, e := range queue {
+			select {
+			case s.ch <- e:
+			case <-s.done:
+				return
+			}
+		}
+
+		select {
+		case <-s.wake:
+		case <-s.done:
+			return
+		}
+	}
+}
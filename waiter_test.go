@@ -0,0 +1,244 @@
+package clock_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LopatkinEvgeniy/clock"
+)
+
+func TestFakeClockSubscribe(t *testing.T) {
+	c := clock.NewFakeClock()
+	events, cancel := c.Subscribe()
+
+	timer := c.NewTimer(time.Minute)
+	timer.Reset(30 * time.Second)
+	ticker := c.NewTicker(time.Hour)
+	c.AfterFunc(time.Hour, func() {})
+	c.Advance(time.Minute)
+	ticker.Stop()
+
+	expectedEvents := []clock.WaiterEvent{
+		{Kind: clock.KindTimer, Action: clock.ActionRegistered, Duration: time.Minute, TriggerTime: (time.Time{}).Add(time.Minute)},
+		{Kind: clock.KindTimer, Action: clock.ActionReset, Duration: 30 * time.Second, TriggerTime: (time.Time{}).Add(30 * time.Second)},
+		{Kind: clock.KindTicker, Action: clock.ActionRegistered, Duration: time.Hour, TriggerTime: (time.Time{}).Add(time.Hour)},
+		{Kind: clock.KindAfterFunc, Action: clock.ActionRegistered, Duration: time.Hour, TriggerTime: (time.Time{}).Add(time.Hour)},
+		{Kind: clock.KindTimer, Action: clock.ActionFired, Duration: 30 * time.Second, TriggerTime: (time.Time{}).Add(30 * time.Second)},
+		{Kind: clock.KindTicker, Action: clock.ActionStopped, Duration: time.Hour, TriggerTime: (time.Time{}).Add(time.Hour)},
+	}
+	for _, expected := range expectedEvents {
+		actual := <-events
+		if expected != actual {
+			t.Fatalf("Unexpected event, expected=%+v, actual=%+v", expected, actual)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestFakeClockSubscribeCancel(t *testing.T) {
+	c := clock.NewFakeClock()
+	events, cancel := c.Subscribe()
+
+	c.NewTimer(time.Minute)
+	cancel()
+	cancel()
+
+	for range events {
+	}
+
+	// The clock must not be blocked by the cancelled subscription.
+	for i := 0; i < 100; i++ {
+		c.NewTimer(time.Minute)
+	}
+	c.Advance(time.Minute)
+}
+
+func TestWaiterKindString(t *testing.T) {
+	kinds := map[clock.WaiterKind]string{
+		clock.KindTimer:     "timer",
+		clock.KindTicker:    "ticker",
+		clock.KindAfterFunc: "AfterFunc",
+		clock.KindSleeper:   "sleeper",
+		clock.KindTick:      "Tick",
+		clock.KindAfter:     "After",
+	}
+	for kind, expected := range kinds {
+		if actual := kind.String(); expected != actual {
+			t.Fatalf("Unexpected kind string, expected=%s, actual=%s", expected, actual)
+		}
+	}
+}
+
+func TestFakeClockWaiters(t *testing.T) {
+	c := clock.NewFakeClock(clock.WithCreationStacks())
+
+	if waiters := c.Waiters(); len(waiters) != 0 {
+		t.Fatalf("Unexpected waiters: %v", waiters)
+	}
+
+	c.NewTimer(5 * time.Minute)
+	c.NewTicker(time.Minute)
+	c.AfterFunc(3*time.Minute, func() {})
+	c.Tick(4 * time.Minute)
+	go func() {
+		c.Sleep(2 * time.Minute)
+	}()
+	c.BlockUntil(5)
+
+	expected := []clock.WaiterInfo{
+		{Kind: clock.KindTicker, Duration: time.Minute, TriggerTime: (time.Time{}).Add(time.Minute)},
+		{Kind: clock.KindSleeper, Duration: 2 * time.Minute, TriggerTime: (time.Time{}).Add(2 * time.Minute)},
+		{Kind: clock.KindAfterFunc, Duration: 3 * time.Minute, TriggerTime: (time.Time{}).Add(3 * time.Minute)},
+		{Kind: clock.KindTick, Duration: 4 * time.Minute, TriggerTime: (time.Time{}).Add(4 * time.Minute)},
+		{Kind: clock.KindTimer, Duration: 5 * time.Minute, TriggerTime: (time.Time{}).Add(5 * time.Minute)},
+	}
+
+	actual := c.Waiters()
+	if len(expected) != len(actual) {
+		t.Fatalf("Unexpected waiters count, expected=%d, actual=%d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if expected[i].Kind != actual[i].Kind || expected[i].Duration != actual[i].Duration || expected[i].TriggerTime != actual[i].TriggerTime {
+			t.Fatalf("Unexpected waiter, expected=%s, actual=%s", expected[i], actual[i])
+		}
+		if !strings.Contains(actual[i].CallSite, "waiter_test.go:") {
+			t.Fatalf("Unexpected waiter's call site: %s", actual[i].CallSite)
+		}
+	}
+
+	c.Advance(2 * time.Minute)
+	if n := len(c.Waiters()); n != 4 {
+		t.Fatalf("Unexpected waiters count, expected=4, actual=%d", n)
+	}
+}
+
+func TestFakeClockWaitersWithoutCreationStacks(t *testing.T) {
+	c := clock.NewFakeClock()
+	c.NewTimer(time.Minute)
+
+	w := c.Waiters()[0]
+	if w.CallSite != "" || w.Stack() != "" {
+		t.Fatalf("Unexpected creation stack, call site=%q, stack=%q", w.CallSite, w.Stack())
+	}
+	if expected := "timer: duration=1m0s trigger=0001-01-01 00:01:00 +0000 UTC"; w.String() != expected {
+		t.Fatalf("Unexpected waiter's description, expected=%s, actual=%s", expected, w)
+	}
+}
+
+func TestFakeClockBlockUntilKind(t *testing.T) {
+	c := clock.NewFakeClock()
+	c.NewTicker(time.Second)
+	c.NewTimer(time.Second)
+
+	doneCh := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func() {
+			c.Sleep(time.Minute)
+			doneCh <- struct{}{}
+		}()
+	}
+
+	c.BlockUntilKind(clock.KindSleeper, 2)
+	c.After(time.Hour)
+
+	counts := map[clock.WaiterKind]int{
+		clock.KindSleeper:   2,
+		clock.KindTicker:    1,
+		clock.KindTimer:     1,
+		clock.KindAfter:     1,
+		clock.KindAfterFunc: 0,
+		clock.KindTick:      0,
+	}
+	for kind, expected := range counts {
+		if actual := c.WaitersCountKind(kind); expected != actual {
+			t.Fatalf("Unexpected %s waiters count, expected=%d, actual=%d", kind, expected, actual)
+		}
+	}
+
+	c.Advance(time.Minute)
+	<-doneCh
+	<-doneCh
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.BlockUntilKindContext(ctx, clock.KindSleeper, 0); err != nil {
+		t.Fatalf("Unexpected BlockUntilKindContext error: %s", err)
+	}
+	if err := c.BlockUntilKindContext(ctx, clock.KindSleeper, 1); err != context.DeadlineExceeded {
+		t.Fatalf("Unexpected BlockUntilKindContext error, expected=%v, actual=%v", context.DeadlineExceeded, err)
+	}
+	if n := c.WaitersCount(); n != 2 {
+		t.Fatalf("Unexpected waiters count, expected=2, actual=%d", n)
+	}
+}
+
+func TestFakeClockLabels(t *testing.T) {
+	c := clock.NewFakeClock()
+	c.Advance(time.Minute)
+
+	backoff := clock.Labeled(c, "retry-backoff")
+	heartbeat := clock.Labeled(c, "heartbeat")
+
+	go func() {
+		backoff.Sleep(time.Hour)
+	}()
+	c.BlockUntilLabel("retry-backoff")
+
+	timer := backoff.NewTimer(2 * time.Hour)
+	ticker := heartbeat.NewTicker(10 * time.Minute)
+	unlabeled := c.NewTimer(time.Hour)
+
+	for _, w := range c.Waiters() {
+		if w.Kind == clock.KindTicker && w.Label != "heartbeat" {
+			t.Fatalf("Unexpected ticker's label: %q", w.Label)
+		}
+	}
+
+	fired := c.FireLabel("retry-backoff")
+	if fired != 2 {
+		t.Fatalf("Unexpected fired count, expected=2, actual=%d", fired)
+	}
+	c.BlockUntilKind(clock.KindSleeper, 0)
+
+	expectedTime := (time.Time{}).Add(time.Minute)
+	if actualTime := <-timer.Chan(); expectedTime != actualTime {
+		t.Fatalf("Unexpected time received from the channel, expected=%s, actual=%s", expectedTime, actualTime)
+	}
+	if now := c.Now(); now != expectedTime {
+		t.Fatalf("unexpected now result, expected: %s, actual: %s", expectedTime, now)
+	}
+	select {
+	case <-unlabeled.Chan():
+		t.Fatal("Unexpected timer's channel receive")
+	case <-ticker.Chan():
+		t.Fatal("Unexpected ticker's channel receive")
+	default:
+	}
+
+	if fired := c.FireLabel("heartbeat"); fired != 1 {
+		t.Fatalf("Unexpected fired count, expected=1, actual=%d", fired)
+	}
+	<-ticker.Chan()
+
+	c.Advance(10 * time.Minute)
+	expectedTime = (time.Time{}).Add(11 * time.Minute)
+	if actualTime := <-ticker.Chan(); expectedTime != actualTime {
+		t.Fatalf("Unexpected time received from the ticker's channel, expected=%s, actual=%s", expectedTime, actualTime)
+	}
+
+	if n := c.WaitersCount(); n != 2 {
+		t.Fatalf("Unexpected waiters count, expected=2, actual=%d", n)
+	}
+}
+
+func TestLabeledRealClock(t *testing.T) {
+	c := clock.NewRealClock()
+	if clock.Labeled(c, "label") != c {
+		t.Fatal("Unexpected labeled real clock")
+	}
+}